@@ -0,0 +1,213 @@
+package comphouse
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Address is a postal address as returned by the Companies House API. Not
+// every resource populates every field, e.g. search results never include
+// Premises, but the same type is used everywhere so addresses can be handled
+// uniformly
+type Address struct {
+	AddressLine1 string `json:"address_line_1"`
+	AddressLine2 string `json:"address_line_2"`
+	CareOf       string `json:"care_of"`
+	Country      string `json:"country"`
+	Locality     string `json:"locality"`
+	PoBox        string `json:"po_box"`
+	PostalCode   string `json:"postal_code"`
+	Premises     string `json:"premises"`
+	Region       string `json:"region"`
+}
+
+// IsZero reports whether every field of the address is empty
+func (m Address) IsZero() bool {
+	return m == Address{}
+}
+
+// Postcode parses the address's PostalCode as a UK postcode. Addresses
+// outside the UK will usually return an error wrapping ErrInvalidPostcode
+func (m Address) Postcode() (Postcode, error) {
+	return ParsePostcode(m.PostalCode)
+}
+
+// Lines returns the address as postal lines, in the order they would be
+// written on an envelope, omitting empty fields. Numeric premises such as
+// "10" or "10A" are written on the same line as AddressLine1 and the postal
+// code is normalised if it's a valid UK postcode
+func (m Address) Lines() []string {
+	var lines []string
+
+	add := func(parts ...string) {
+		var fields []string
+
+		for _, p := range parts {
+			if p = strings.TrimSpace(p); p != "" {
+				fields = append(fields, p)
+			}
+		}
+
+		if len(fields) > 0 {
+			lines = append(lines, strings.Join(fields, " "))
+		}
+	}
+
+	if careOf := strings.TrimSpace(m.CareOf); careOf != "" {
+		add("c/o", careOf)
+	}
+
+	if poBox := strings.TrimSpace(m.PoBox); poBox != "" {
+		add("PO Box", poBox)
+	}
+
+	if isStreetNumber(m.Premises) {
+		add(m.Premises, m.AddressLine1)
+	} else {
+		add(m.Premises)
+		add(m.AddressLine1)
+	}
+
+	add(m.AddressLine2)
+	add(m.Locality)
+	add(m.Region)
+
+	if postcode, err := m.Postcode(); err == nil {
+		add(postcode.String())
+	} else {
+		add(m.PostalCode)
+	}
+
+	add(m.Country)
+
+	return lines
+}
+
+// String formats the address on a single line with its lines separated by
+// commas
+func (m Address) String() string {
+	return strings.Join(m.Lines(), ", ")
+}
+
+// MultiLine formats the address with each of its lines separated by a
+// newline
+func (m Address) MultiLine() string {
+	return strings.Join(m.Lines(), "\n")
+}
+
+// pattern matching premises that are a street number, e.g. "10", "10A" or
+// "10-12"
+var streetNumberPattern = regexp.MustCompile(`^[0-9]+[A-Za-z]?(-[0-9]+[A-Za-z]?)?$`)
+
+// isStreetNumber reports whether premises are a street number rather than
+// the name of a building
+func isStreetNumber(premises string) bool {
+	return streetNumberPattern.MatchString(strings.TrimSpace(premises))
+}
+
+// patterns for the outward and inward parts of a UK postcode
+var (
+	outwardCodePattern = regexp.MustCompile(`^([A-PR-UWYZ][0-9][0-9A-HJKPSTUW]?|[A-PR-UWYZ][A-HK-Y][0-9][0-9ABEHMNPRVWXY]?)$`)
+	inwardCodePattern  = regexp.MustCompile(`^[0-9][ABD-HJLNP-UW-Z]{2}$`)
+)
+
+// Postcode is a UK postcode split into its outward code, identifying the
+// postcode area and district, and inward code, identifying the sector and
+// unit. The zero value is an empty postcode
+type Postcode struct {
+	Outward string
+	Inward  string
+}
+
+// ParsePostcode parses and normalises a UK postcode. Case and whitespace are
+// ignored so "sw1a1aa" and " SW1A 1AA " both parse as "SW1A 1AA". An error
+// wrapping ErrInvalidPostcode is returned if the input isn't a valid postcode
+func ParsePostcode(s string) (Postcode, error) {
+	normalised := strings.ToUpper(strings.Join(strings.Fields(s), ""))
+
+	if len(normalised) < 5 || len(normalised) > 7 {
+		return Postcode{}, fmt.Errorf("%w %q", ErrInvalidPostcode, s)
+	}
+
+	p := Postcode{
+		Outward: normalised[:len(normalised)-3],
+		Inward:  normalised[len(normalised)-3:],
+	}
+
+	// GIR 0AA is a non-geographic postcode that doesn't follow the usual
+	// format
+	if p.Outward == "GIR" && p.Inward == "0AA" {
+		return p, nil
+	}
+
+	if !outwardCodePattern.MatchString(p.Outward) || !inwardCodePattern.MatchString(p.Inward) {
+		return Postcode{}, fmt.Errorf("%w %q", ErrInvalidPostcode, s)
+	}
+
+	return p, nil
+}
+
+// IsValidPostcode reports whether a string is a valid UK postcode
+func IsValidPostcode(s string) bool {
+	_, err := ParsePostcode(s)
+	return err == nil
+}
+
+// IsZero reports whether the postcode is empty
+func (m Postcode) IsZero() bool {
+	return m == Postcode{}
+}
+
+// String returns the normalised postcode with a single space between its
+// outward and inward codes
+func (m Postcode) String() string {
+	if m.IsZero() {
+		return ""
+	}
+
+	return m.Outward + " " + m.Inward
+}
+
+// Area returns the postcode area, the letters at the start of the outward
+// code, e.g. "SW" for "SW1A 1AA"
+func (m Postcode) Area() string {
+	if i := strings.IndexAny(m.Outward, "0123456789"); i >= 0 {
+		return m.Outward[:i]
+	}
+
+	return m.Outward
+}
+
+// Sector returns the postcode sector, the outward code followed by the first
+// character of the inward code, e.g. "SW1A 1" for "SW1A 1AA"
+func (m Postcode) Sector() string {
+	if m.IsZero() {
+		return ""
+	}
+
+	return m.Outward + " " + m.Inward[:1]
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (m Postcode) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Empty input results in
+// the zero Postcode
+func (m *Postcode) UnmarshalText(text []byte) error {
+	if strings.TrimSpace(string(text)) == "" {
+		*m = Postcode{}
+		return nil
+	}
+
+	p, err := ParsePostcode(string(text))
+	if err != nil {
+		return err
+	}
+
+	*m = p
+
+	return nil
+}
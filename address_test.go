@@ -0,0 +1,139 @@
+package comphouse
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressLines(t *testing.T) {
+	tests := []struct {
+		inp Address
+		exp []string
+	}{
+		{
+			Address{Premises: "10", AddressLine1: "Downing Street", Locality: "London", PostalCode: "sw1a2aa", Country: "United Kingdom"},
+			[]string{"10 Downing Street", "London", "SW1A 2AA", "United Kingdom"},
+		},
+		{
+			Address{CareOf: "Jane Smith", Premises: "Crown Way House", AddressLine1: "Crown Way", Locality: "Cardiff", Region: "South Glamorgan", PostalCode: "CF14 3UZ"},
+			[]string{"c/o Jane Smith", "Crown Way House", "Crown Way", "Cardiff", "South Glamorgan", "CF14 3UZ"},
+		},
+		{
+			Address{PoBox: "123", AddressLine1: " High Street ", AddressLine2: "Old Town", Locality: "Edinburgh", PostalCode: "EH1 1AA"},
+			[]string{"PO Box 123", "High Street", "Old Town", "Edinburgh", "EH1 1AA"},
+		},
+		{
+			Address{Premises: "14A-16", AddressLine1: "Rue de Rivoli", Locality: "Paris", PostalCode: "75001", Country: "France"},
+			[]string{"14A-16 Rue de Rivoli", "Paris", "75001", "France"},
+		},
+		{Address{}, nil},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.exp, test.inp.Lines())
+	}
+}
+
+func TestAddressFormatting(t *testing.T) {
+	assert := assert.New(t)
+
+	a := Address{Premises: "10", AddressLine1: "Downing Street", Locality: "London", PostalCode: "SW1A 2AA"}
+
+	assert.Equal("10 Downing Street, London, SW1A 2AA", a.String())
+	assert.Equal("10 Downing Street\nLondon\nSW1A 2AA", a.MultiLine())
+	assert.False(a.IsZero())
+	assert.True(Address{}.IsZero())
+
+	p, err := a.Postcode()
+	assert.NoError(err)
+	assert.Equal(Postcode{Outward: "SW1A", Inward: "2AA"}, p)
+}
+
+func TestAddressDecodesResources(t *testing.T) {
+	assert := assert.New(t)
+
+	var r RegisteredOfficeAddress
+
+	err := json.Unmarshal([]byte(`{
+		"address_line_1": "Crown Way",
+		"etag": "abc",
+		"locality": "Cardiff",
+		"postal_code": "CF14 3UZ",
+		"premises": "Companies House"
+	}`), &r)
+
+	assert.NoError(err)
+	assert.Equal("abc", r.Etag)
+	assert.Equal("Companies House, Crown Way, Cardiff, CF14 3UZ", r.Address.String())
+}
+
+func TestParsePostcode(t *testing.T) {
+	tests := []struct {
+		inp    string
+		exp    string
+		area   string
+		sector string
+	}{
+		{"SW1A 1AA", "SW1A 1AA", "SW", "SW1A 1"},
+		{"sw1a1aa", "SW1A 1AA", "SW", "SW1A 1"},
+		{" m1  1ae ", "M1 1AE", "M", "M1 1"},
+		{"B33 8TH", "B33 8TH", "B", "B33 8"},
+		{"CR2 6XH", "CR2 6XH", "CR", "CR2 6"},
+		{"DN55 1PT", "DN55 1PT", "DN", "DN55 1"},
+		{"W1A 0AX", "W1A 0AX", "W", "W1A 0"},
+		{"GIR 0AA", "GIR 0AA", "GIR", "GIR 0"},
+	}
+
+	for _, test := range tests {
+		p, err := ParsePostcode(test.inp)
+
+		assert.NoError(t, err, test.inp)
+		assert.Equal(t, test.exp, p.String())
+		assert.Equal(t, test.area, p.Area())
+		assert.Equal(t, test.sector, p.Sector())
+		assert.True(t, IsValidPostcode(test.inp))
+	}
+}
+
+func TestParsePostcodeErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"SW1A",
+		"SW1A 1AAA",
+		"QW1 1AA",
+		"SW1A 1CA",
+		"1AB 1AA",
+		"75001",
+		"SW1A-1AA",
+	}
+
+	for _, test := range tests {
+		_, err := ParsePostcode(test)
+
+		assert.ErrorIs(t, err, ErrInvalidPostcode, test)
+		assert.False(t, IsValidPostcode(test))
+	}
+}
+
+func TestPostcodeText(t *testing.T) {
+	assert := assert.New(t)
+
+	var v struct {
+		Postcode Postcode `json:"postcode"`
+	}
+
+	assert.NoError(json.Unmarshal([]byte(`{"postcode": "cf143uz"}`), &v))
+	assert.Equal("CF14 3UZ", v.Postcode.String())
+
+	b, err := json.Marshal(v)
+	assert.NoError(err)
+	assert.JSONEq(`{"postcode": "CF14 3UZ"}`, string(b))
+
+	assert.NoError(json.Unmarshal([]byte(`{"postcode": ""}`), &v))
+	assert.True(v.Postcode.IsZero())
+	assert.Equal("", v.Postcode.Sector())
+
+	assert.ErrorIs(json.Unmarshal([]byte(`{"postcode": "nope"}`), &v), ErrInvalidPostcode)
+}
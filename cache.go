@@ -0,0 +1,361 @@
+package comphouse
+
+import (
+	"bytes"
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of responses held by an LRUCache created
+// with a non-positive size
+const DefaultCacheSize = 1000
+
+// CachedResponse is a successful API response stored in a Cache
+type CachedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+	ETag       string      `json:"etag"`
+
+	// Expires is when the response must be revalidated with the API before
+	// it is used again
+	Expires time.Time `json:"expires"`
+}
+
+// fresh reports whether the response can be used without revalidation
+func (m *CachedResponse) fresh(now time.Time) bool {
+	return now.Before(m.Expires)
+}
+
+// response creates a new http.Response for req from the cached response
+func (m *CachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Status:        strconv.Itoa(m.StatusCode) + " " + http.StatusText(m.StatusCode),
+		StatusCode:    m.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        m.Header.Clone(),
+		Body:          io.NopCloser(bytes.NewReader(m.Body)),
+		ContentLength: int64(len(m.Body)),
+		Request:       req,
+	}
+}
+
+// Cache is implemented by stores that can hold API responses. Responses are
+// keyed by their request URL. Implementations must be safe for concurrent use
+// and must not modify responses passed to Set
+type Cache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, resp *CachedResponse)
+}
+
+// CachePolicy controls how long a Client serves cached responses before
+// revalidating them with the API using their ETag
+type CachePolicy struct {
+	// TTL is how long responses are served from the cache without contacting
+	// the API. Zero means every request is revalidated, which still saves
+	// bandwidth as unchanged resources are returned as a 304 Not Modified
+	TTL time.Duration
+
+	// Endpoints overrides TTL for requests whose path matches a template,
+	// such as "/company/{company_number}/officers". Segments wrapped in
+	// braces match any value. If several templates match a path the most
+	// specific, the one with the fewest such segments, is used with ties
+	// broken by comparing the templates alphabetically
+	Endpoints map[string]time.Duration
+}
+
+// ttl returns the TTL for a request path
+func (m CachePolicy) ttl(path string) time.Duration {
+	var (
+		best      string
+		wildcards = -1
+	)
+
+	for template := range m.Endpoints {
+		if !matchPathTemplate(template, path) {
+			continue
+		}
+
+		// ties are broken by comparing templates so the result doesn't
+		// depend on map iteration order
+		n := countPathWildcards(template)
+		if wildcards < 0 || n < wildcards || (n == wildcards && template < best) {
+			best, wildcards = template, n
+		}
+	}
+
+	if wildcards < 0 {
+		return m.TTL
+	}
+
+	return m.Endpoints[best]
+}
+
+// cacheKey returns the key a request's response is cached under. The Accept
+// header is included as the Document API serves different content for the
+// same URL depending on it
+func cacheKey(req *http.Request) string {
+	key := req.URL.String()
+
+	if accept := req.Header.Get("Accept"); accept != "" {
+		key += " " + accept
+	}
+
+	return key
+}
+
+// cacheable reports whether a request's response may be stored in a Cache.
+// Only GET requests for JSON resources are cached so documents fetched from
+// the Document API are streamed rather than buffered in memory
+func cacheable(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+
+	accept := req.Header.Get("Accept")
+	if accept == "" {
+		return true
+	}
+
+	for _, v := range strings.Split(accept, ",") {
+		if isJSONMediaType(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isJSONMediaType reports whether a Content-Type or Accept value is JSON
+func isJSONMediaType(v string) bool {
+	mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(v))
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// doCached executes a GET request using the Client's Cache. Fresh responses
+// are returned without contacting the API, stale ones are revalidated using
+// If-None-Match and served from the cache if the API responds with a 304.
+// Only successful JSON responses are stored
+func (m *Client) doCached(req *http.Request) (*http.Response, error) {
+	key := cacheKey(req)
+	now := time.Now()
+
+	span := SpanFromContext(req.Context())
+
+	cached, ok := m.Cache.Get(key)
+	if ok && cached.fresh(now) {
+		span.SetAttribute(SpanAttrCache, SpanCacheHit)
+
+		if m.Logger != nil {
+			m.Logger.Log(LogLevelDebug, "cache hit", LogField{"method", req.Method}, LogField{"path", req.URL.Path})
+		}
+
+		return cached.response(req), nil
+	}
+
+	if ok && cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+
+	resp, err := m.send(req)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := m.CachePolicy.ttl(req.URL.Path)
+
+	if ok && resp.StatusCode == http.StatusNotModified {
+		span.SetAttribute(SpanAttrCache, SpanCacheRevalidated)
+		discard(resp)
+
+		refreshed := *cached
+		refreshed.Expires = now.Add(ttl)
+		m.Cache.Set(key, &refreshed)
+
+		return refreshed.response(req), nil
+	}
+
+	span.SetAttribute(SpanAttrCache, SpanCacheMiss)
+
+	etag := resp.Header.Get("ETag")
+
+	if resp.StatusCode != http.StatusOK || (etag == "" && ttl <= 0) || !isJSONMediaType(resp.Header.Get("Content-Type")) {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, contextError(req.Context(), err)
+	}
+
+	cached = &CachedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header.Clone(),
+		Body:       body,
+		ETag:       etag,
+		Expires:    now.Add(ttl),
+	}
+
+	m.Cache.Set(key, cached)
+
+	return cached.response(req), nil
+}
+
+// LRUCache is an in-memory Cache holding a fixed number of responses. The
+// least recently used response is evicted when it is full
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	resp *CachedResponse
+}
+
+// NewLRUCache creates a new LRUCache holding up to size responses. A
+// non-positive size uses DefaultCacheSize
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+
+	return &LRUCache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the response cached for key, marking it as recently used
+func (m *LRUCache) Get(key string) (*CachedResponse, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	m.order.MoveToFront(e)
+
+	return e.Value.(*lruEntry).resp, true
+}
+
+// Set caches a response for key, evicting the least recently used response
+// if the cache is full
+func (m *LRUCache) Set(key string, resp *CachedResponse) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.entries[key]; ok {
+		e.Value.(*lruEntry).resp = resp
+		m.order.MoveToFront(e)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&lruEntry{key: key, resp: resp})
+
+	for m.order.Len() > m.size {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of responses in the cache
+func (m *LRUCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.order.Len()
+}
+
+// DiskCache is a Cache that stores each response as a JSON file in a
+// directory, allowing responses to be reused between runs. Files that can't
+// be read or decoded are treated as cache misses and write failures are
+// ignored, so a broken cache only costs extra requests
+type DiskCache struct {
+	Dir string
+}
+
+// NewDiskCache creates a new DiskCache storing responses in dir, creating the
+// directory if it doesn't exist
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	return &DiskCache{Dir: dir}, nil
+}
+
+// path returns the file a key is stored in
+func (m *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(m.Dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the response cached for key
+func (m *DiskCache) Get(key string) (*CachedResponse, bool) {
+	b, err := os.ReadFile(m.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	resp := &CachedResponse{}
+
+	if err := json.Unmarshal(b, resp); err != nil {
+		return nil, false
+	}
+
+	return resp, true
+}
+
+// Set caches a response for key. The file is written to a temporary file
+// first so concurrent readers never see a partially written response
+func (m *DiskCache) Set(key string, resp *CachedResponse) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+
+	f, err := os.CreateTemp(m.Dir, ".tmp-")
+	if err != nil {
+		return
+	}
+
+	_, err = f.Write(b)
+
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), m.path(key))
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+	}
+}
@@ -0,0 +1,343 @@
+package comphouse
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestCachingServer(etag string, requests *int, conditional *[]string) (*Client, func()) {
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		*conditional = append(*conditional, r.Header.Get("If-None-Match"))
+
+		if etag != "" && r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		if etag != "" {
+			w.Header().Set("ETag", etag)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		w.Write([]byte(`{"company_name": "Test Company"}`))
+	})
+
+	c.Cache = NewLRUCache(10)
+
+	return c, ts.Close
+}
+
+func TestClientCacheRevalidatesWithETag(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		requests    int
+		conditional []string
+	)
+
+	c, done := createTestCachingServer(`"abc"`, &requests, &conditional)
+	defer done()
+
+	for i := 0; i < 3; i++ {
+		profile, err := c.Company(EnglishCompanyNo(1)).Profile()
+
+		assert.NoError(err)
+		assert.Equal("Test Company", profile.CompanyName)
+	}
+
+	assert.Equal(3, requests)
+	assert.Equal([]string{"", `"abc"`, `"abc"`}, conditional)
+}
+
+func TestClientCacheServesFreshResponses(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		requests    int
+		conditional []string
+	)
+
+	c, done := createTestCachingServer(`"abc"`, &requests, &conditional)
+	defer done()
+
+	c.CachePolicy.TTL = time.Hour
+
+	for i := 0; i < 3; i++ {
+		profile, err := c.Company(EnglishCompanyNo(1)).Profile()
+
+		assert.NoError(err)
+		assert.Equal("Test Company", profile.CompanyName)
+	}
+
+	assert.Equal(1, requests)
+}
+
+func TestClientCacheEndpointTTL(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		requests    int
+		conditional []string
+	)
+
+	c, done := createTestCachingServer(`"abc"`, &requests, &conditional)
+	defer done()
+
+	c.CachePolicy.Endpoints = map[string]time.Duration{
+		"/company/{company_number}": time.Hour,
+	}
+
+	company := c.Company(EnglishCompanyNo(1))
+
+	for i := 0; i < 2; i++ {
+		_, err := company.Profile()
+		assert.NoError(err)
+
+		_, err = company.Insolvency()
+		assert.NoError(err)
+	}
+
+	// the profile is only fetched once but insolvency is revalidated
+	assert.Equal(3, requests)
+}
+
+func TestCachePolicyPrefersMostSpecificTemplate(t *testing.T) {
+	assert := assert.New(t)
+
+	p := CachePolicy{
+		TTL: time.Second,
+		Endpoints: map[string]time.Duration{
+			"/company/{company_number}/{resource}": time.Minute,
+			"/company/{company_number}/officers":   time.Hour,
+			"/{resource}/{id}/officers":            time.Millisecond,
+			"/{a}/{b}/{c}":                         time.Microsecond,
+			"/{x}/{y}/{z}":                         time.Nanosecond,
+		},
+	}
+
+	// run repeatedly as map iteration order is randomised
+	for i := 0; i < 50; i++ {
+		assert.Equal(time.Hour, p.ttl("/company/00000001/officers"))
+		assert.Equal(time.Minute, p.ttl("/company/00000001/charges"))
+		assert.Equal(time.Microsecond, p.ttl("/officers/abc/appointments"))
+		assert.Equal(time.Second, p.ttl("/company/00000001"))
+	}
+}
+
+func TestClientCacheSkipsResponsesWithoutETag(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		requests    int
+		conditional []string
+	)
+
+	c, done := createTestCachingServer("", &requests, &conditional)
+	defer done()
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Company(EnglishCompanyNo(1)).Profile()
+		assert.NoError(err)
+	}
+
+	assert.Equal(2, requests)
+	assert.Equal(0, c.Cache.(*LRUCache).Len())
+}
+
+func TestClientCacheSkipsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"abc"`)
+		w.WriteHeader(404)
+	})
+
+	defer ts.Close()
+
+	c.Cache = NewLRUCache(10)
+	c.CachePolicy.TTL = time.Hour
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Company(EnglishCompanyNo(1)).Profile()
+		assert.ErrorIs(err, ErrNotFound)
+	}
+
+	assert.Equal(2, requests)
+}
+
+func TestClientCacheSkipsDocumentContent(t *testing.T) {
+	assert := assert.New(t)
+
+	content := bytes.Repeat([]byte("%PDF"), 1<<20)
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("ETag", `"abc"`)
+
+		if r.URL.Path == "/document/d1" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"id": "d1"}`))
+			return
+		}
+
+		w.Header().Set("Content-Type", DocumentPDF)
+		w.Write(content)
+	})
+
+	defer ts.Close()
+
+	c.DocumentHost = ts.Listener.Addr().String()
+	c.Cache = NewLRUCache(10)
+	c.CachePolicy.TTL = time.Hour
+
+	for i := 0; i < 2; i++ {
+		var buff bytes.Buffer
+
+		n, err := c.Document("d1").Content("", &buff)
+
+		assert.NoError(err)
+		assert.Equal(int64(len(content)), n)
+		assert.Equal(content, buff.Bytes())
+	}
+
+	assert.Equal(2, requests)
+	assert.Equal(0, c.Cache.(*LRUCache).Len())
+
+	// document metadata is JSON so is still cached
+	for i := 0; i < 2; i++ {
+		_, err := c.Document("d1").Metadata()
+		assert.NoError(err)
+	}
+
+	assert.Equal(3, requests)
+	assert.Equal(1, c.Cache.(*LRUCache).Len())
+}
+
+func TestClientCacheSkipsNonJSONResponses(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.Header().Set("ETag", `"abc"`)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`{}`))
+	})
+
+	defer ts.Close()
+
+	c.Cache = NewLRUCache(10)
+
+	for i := 0; i < 2; i++ {
+		resp, err := c.Get("/company/00000001")
+		assert.NoError(err)
+
+		resp.Body.Close()
+	}
+
+	assert.Equal(2, requests)
+	assert.Equal(0, c.Cache.(*LRUCache).Len())
+}
+
+func TestCacheable(t *testing.T) {
+	tests := []struct {
+		method string
+		accept string
+		exp    bool
+	}{
+		{"GET", "", true},
+		{"GET", "application/json", true},
+		{"GET", "application/json; charset=utf-8", true},
+		{"GET", "application/pdf, application/json", true},
+		{"GET", "application/pdf", false},
+		{"GET", "application/xhtml+xml", false},
+		{"POST", "", false},
+	}
+
+	for _, test := range tests {
+		req, _ := http.NewRequest(test.method, "/document/d1/content", nil)
+		if test.accept != "" {
+			req.Header.Set("Accept", test.accept)
+		}
+
+		assert.Equal(t, test.exp, cacheable(req), test.accept)
+	}
+}
+
+func TestLRUCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewLRUCache(2)
+
+	c.Set("a", &CachedResponse{ETag: "a"})
+	c.Set("b", &CachedResponse{ETag: "b"})
+
+	_, ok := c.Get("a")
+	assert.True(ok)
+
+	c.Set("c", &CachedResponse{ETag: "c"})
+
+	_, ok = c.Get("b")
+	assert.False(ok)
+
+	r, ok := c.Get("a")
+	assert.True(ok)
+	assert.Equal("a", r.ETag)
+
+	c.Set("a", &CachedResponse{ETag: "a2"})
+
+	r, _ = c.Get("a")
+	assert.Equal("a2", r.ETag)
+	assert.Equal(2, c.Len())
+}
+
+func TestDiskCache(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "comphouse-cache-")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	c, err := NewDiskCache(dir)
+	assert.NoError(err)
+
+	_, ok := c.Get("https://localhost/company/00000001")
+	assert.False(ok)
+
+	expires := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	c.Set("https://localhost/company/00000001", &CachedResponse{
+		StatusCode: 200,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       []byte(`{}`),
+		ETag:       `"abc"`,
+		Expires:    expires,
+	})
+
+	r, ok := c.Get("https://localhost/company/00000001")
+
+	assert.True(ok)
+	assert.Equal(200, r.StatusCode)
+	assert.Equal("application/json", r.Header.Get("Content-Type"))
+	assert.Equal([]byte(`{}`), r.Body)
+	assert.Equal(`"abc"`, r.ETag)
+	assert.True(expires.Equal(r.Expires))
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(err)
+	assert.Len(files, 1)
+}
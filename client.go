@@ -1,7 +1,9 @@
 package comphouse
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,18 +13,29 @@ import (
 
 // Default values used when creating a new Client
 const (
-	DefaultProtocol = "https"
-	DefaultTimeout  = time.Second * 30
+	DefaultProtocol     = "https"
+	DefaultTimeout      = time.Second * 30
+	DefaultDocumentHost = "document-api.company-information.service.gov.uk"
 )
 
 // Client is a http.Client wrapper to make interacting with the Companies
 // House API easier
 type Client struct {
-	Auth     Authenticator
-	Host     string
-	Protocol string
-	Hooks    Hooks
-	HTTP     *http.Client
+	Auth         Authenticator
+	Host         string
+	DocumentHost string
+	StreamHost   string
+	Protocol     string
+	UserAgent    string
+	Hooks        Hooks
+	HTTP         *http.Client
+	Retry        *RetryPolicy
+	Limiter      *RateLimiter
+	Cache        Cache
+	CachePolicy  CachePolicy
+	Middleware   []Middleware
+	Logger       Logger
+	Tracer       Tracer
 }
 
 // Hooks contains functions that will be executed during the lifecycle
@@ -44,6 +57,23 @@ func (m Hooks) execAfterRequest(resp *http.Response) {
 	}
 }
 
+// Middleware adapts the hooks to a Middleware. AfterRequest hooks are only
+// executed when a response is received
+func (m Hooks) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			m.execBeforeRequest(req)
+
+			resp, err := next.RoundTrip(req)
+			if err == nil {
+				m.execAfterRequest(resp)
+			}
+
+			return resp, err
+		})
+	}
+}
+
 // NewClient creates a new Client for the specified host. Requests will be
 // authenticated using the provided Authenticator
 func NewClient(host string, auth Authenticator) *Client {
@@ -52,24 +82,40 @@ func NewClient(host string, auth Authenticator) *Client {
 	}
 
 	return &Client{
-		Auth:     auth,
-		Host:     host,
-		Protocol: DefaultProtocol,
+		Auth:         auth,
+		Host:         host,
+		DocumentHost: DefaultDocumentHost,
+		StreamHost:   DefaultStreamHost,
+		Protocol:     DefaultProtocol,
 		HTTP: &http.Client{
 			Timeout: DefaultTimeout,
 		},
+		Limiter: NewRateLimiter(DefaultRateLimit, DefaultRateLimitWindow),
 	}
 }
 
-// URL returns a formatted URL for the Client's configured protocol and host
+// URL returns a formatted URL for the Client's configured protocol and host.
+// Absolute URLs, such as the links to other Companies House APIs included in
+// some resources, are returned unchanged
 func (m *Client) URL(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+
 	path = strings.TrimPrefix(path, "/")
 	return fmt.Sprintf("%s://%s/%s", m.Protocol, m.Host, path)
 }
 
 // NewRequest is a helper method to create a new authenticated HTTP request
 func (m *Client) NewRequest(method, path string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, m.URL(path), body)
+	return m.NewRequestContext(context.Background(), method, path, body)
+}
+
+// NewRequestContext is a helper method to create a new authenticated HTTP
+// request bound to the provided context. If the context holds a span, its
+// W3C traceparent header is added to the request
+func (m *Client) NewRequestContext(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, m.URL(path), body)
 	if err != nil {
 		return nil, err
 	}
@@ -78,48 +124,172 @@ func (m *Client) NewRequest(method, path string, body io.Reader) (*http.Request,
 		return nil, err
 	}
 
+	if m.UserAgent != "" {
+		req.Header.Set("User-Agent", m.UserAgent)
+	}
+
+	if traceparent := SpanFromContext(ctx).TraceParent(); traceparent != "" {
+		req.Header.Set("traceparent", traceparent)
+	}
+
 	return req, nil
 }
 
 // Do creates a new request and executes it
 func (m *Client) Do(method, path string, body io.Reader) (*http.Response, error) {
-	req, err := m.NewRequest(method, path, body)
+	return m.DoContext(context.Background(), method, path, body)
+}
+
+// DoContext creates a new request bound to the provided context and executes
+// it. If the context is cancelled or its deadline is exceeded, the context's
+// error is returned. Unsuccessful responses are returned as an *APIError. A
+// span is started for the call using the Client's Tracer
+func (m *Client) DoContext(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	ctx, span := m.startSpan(ctx, method, path)
+	defer span.End()
+
+	req, err := m.NewRequestContext(ctx, method, path, body)
 	if err != nil {
+		span.RecordError(err)
 		return nil, err
 	}
 
-	m.Hooks.execBeforeRequest(req)
+	resp, err := m.DoRequest(req)
+
+	var apiErr *APIError
+
+	switch {
+	case err == nil:
+		span.SetAttribute(SpanAttrStatusCode, resp.StatusCode)
+	case errors.As(err, &apiErr):
+		span.SetAttribute(SpanAttrStatusCode, apiErr.StatusCode)
+		span.RecordError(err)
+	default:
+		span.RecordError(err)
+	}
+
+	return resp, err
+}
+
+// DoRequest executes a request created using NewRequest or NewRequestContext.
+// It can be used when headers need to be added to a request before it is sent.
+// GET requests for JSON resources are served from the Client's Cache when one
+// is configured. Unsuccessful responses are returned as an *APIError
+func (m *Client) DoRequest(req *http.Request) (*http.Response, error) {
+	var (
+		resp *http.Response
+		err  error
+	)
+
+	if m.Cache != nil && cacheable(req) {
+		resp, err = m.doCached(req)
+	} else {
+		resp, err = m.send(req)
+	}
 
-	resp, err := m.HTTP.Do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	m.Hooks.execAfterRequest(resp)
-
 	if err := statusCodeToError(resp.StatusCode); err != nil {
-		return nil, err
+		return nil, newAPIError(resp, err)
 	}
 
 	return resp, nil
 }
 
+// Use appends middleware to the Client's middleware chain
+func (m *Client) Use(middleware ...Middleware) {
+	m.Middleware = append(m.Middleware, middleware...)
+}
+
+// chain wraps a RoundTripper with the Client's hooks and middleware. Hooks
+// are outermost, followed by middleware in the order it was added
+func (m *Client) chain(rt http.RoundTripper) http.RoundTripper {
+	for i := len(m.Middleware) - 1; i >= 0; i-- {
+		rt = m.Middleware[i](rt)
+	}
+
+	return m.Hooks.Middleware()(rt)
+}
+
+// send executes a request, retrying it as configured by the Client's
+// RetryPolicy. Every attempt is paced by the Client's RateLimiter, passes
+// through the Client's hooks and middleware and is logged by its Logger
+func (m *Client) send(req *http.Request) (*http.Response, error) {
+	rt := m.chain(RoundTripperFunc(m.HTTP.Do))
+	span := SpanFromContext(req.Context())
+
+	for attempt := 1; ; attempt++ {
+		if err := m.Limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		span.SetAttribute(SpanAttrAttempts, attempt)
+
+		start := time.Now()
+
+		resp, err := rt.RoundTrip(req.WithContext(context.WithValue(req.Context(), attemptKey{}, attempt)))
+		if err != nil {
+			err = contextError(req.Context(), err)
+		} else {
+			m.Limiter.Update(resp.Header)
+		}
+
+		delay, retry := m.Retry.retry(attempt, req, resp, err)
+
+		m.logAttempt(req, resp, err, attempt, time.Since(start), delay, retry)
+		if !retry {
+			return resp, err
+		}
+
+		if resp != nil {
+			discard(resp)
+		}
+
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+
+		if req, err = rewind(req); err != nil {
+			return nil, err
+		}
+	}
+}
+
 // Get performs a simple GET request to the specified path
 func (m *Client) Get(path string) (*http.Response, error) {
-	return m.Do(http.MethodGet, path, nil)
+	return m.GetContext(context.Background(), path)
+}
+
+// GetContext performs a simple GET request to the specified path using the
+// provided context
+func (m *Client) GetContext(ctx context.Context, path string) (*http.Response, error) {
+	return m.DoContext(ctx, http.MethodGet, path, nil)
 }
 
 // GetJSON performs a GET request to the specified path and attempts to decode
 // the response into the passed interface
 func (m *Client) GetJSON(path string, dest interface{}) error {
-	resp, err := m.Get(path)
+	return m.GetJSONContext(context.Background(), path, dest)
+}
+
+// GetJSONContext performs a GET request to the specified path using the
+// provided context and attempts to decode the response into the passed
+// interface
+func (m *Client) GetJSONContext(ctx context.Context, path string, dest interface{}) error {
+	resp, err := m.GetContext(ctx, path)
 	if err != nil {
 		return err
 	}
 
 	defer resp.Body.Close()
 
-	return json.NewDecoder(resp.Body).Decode(dest)
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return contextError(ctx, err)
+	}
+
+	return nil
 }
 
 // Company creates a new CompanyEndpoint that can be used to fetch company
@@ -133,3 +303,32 @@ func (m *Client) Company(companyNo CompanyNumber) *CompanyEndpoint {
 func (m *Client) Search() *SearchEndpoint {
 	return &SearchEndpoint{Client: m}
 }
+
+// Officer creates a new OfficerEndpoint that can be used to fetch the
+// appointments held by an officer
+func (m *Client) Officer(officerID string) *OfficerEndpoint {
+	return &OfficerEndpoint{Client: m, ID: officerID}
+}
+
+// DisqualifiedOfficer creates a new DisqualifiedOfficerEndpoint that can be
+// used to fetch an officer's disqualifications
+func (m *Client) DisqualifiedOfficer(officerID string) *DisqualifiedOfficerEndpoint {
+	return &DisqualifiedOfficerEndpoint{Client: m, ID: officerID}
+}
+
+// Document creates a new DocumentEndpoint that can be used to fetch filing
+// documents from the Companies House Document API
+func (m *Client) Document(documentID string) *DocumentEndpoint {
+	return &DocumentEndpoint{Client: m, ID: documentID}
+}
+
+// Stream creates a new StreamEndpoint that can be used to consume one of the
+// Companies House Streaming API's streams
+func (m *Client) Stream(stream Stream) *StreamEndpoint {
+	return &StreamEndpoint{
+		Client:           m,
+		Stream:           stream,
+		HeartbeatTimeout: DefaultStreamHeartbeatTimeout,
+		ReconnectDelay:   DefaultStreamReconnectDelay,
+	}
+}
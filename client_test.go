@@ -1,12 +1,14 @@
 package comphouse
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -15,6 +17,7 @@ func createTestServer(f http.HandlerFunc) (*httptest.Server, *Client) {
 	ts := httptest.NewServer(f)
 	c := NewClient(ts.Listener.Addr().String(), nil)
 	c.Protocol = "http"
+	c.StreamHost = c.Host
 	return ts, c
 }
 
@@ -26,6 +29,27 @@ func createTestServerWithResponse(response interface{}) (*httptest.Server, *Clie
 	})
 }
 
+func TestClientURL(t *testing.T) {
+	type test struct {
+		inp string
+		exp string
+	}
+
+	tests := []test{
+		{"/company/00000001", "https://localhost/company/00000001"},
+		{"company/00000001", "https://localhost/company/00000001"},
+		{"https://document-api.company-information.service.gov.uk/document/abc", "https://document-api.company-information.service.gov.uk/document/abc"},
+		{"http://localhost:8080/document/abc", "http://localhost:8080/document/abc"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.inp, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(test.exp, NewClient("localhost", nil).URL(test.inp))
+		})
+	}
+}
+
 func TestClientNewRequestInvalidRequest(t *testing.T) {
 	assert := assert.New(t)
 
@@ -81,7 +105,7 @@ func TestClientDoInvalidStatusCode(t *testing.T) {
 
 	assert.Nil(resp)
 	assert.Error(err)
-	assert.Same(ErrUnauthorized, err)
+	assert.ErrorIs(err, ErrUnauthorized)
 }
 
 func TestClientGetJSONErrorExecutingRequest(t *testing.T) {
@@ -96,7 +120,7 @@ func TestClientGetJSONErrorExecutingRequest(t *testing.T) {
 	err := c.GetJSON("", nil)
 
 	assert.Error(err)
-	assert.Same(ErrNotFound, err)
+	assert.ErrorIs(err, ErrNotFound)
 }
 
 func TestClientGetJSONSuccessful(t *testing.T) {
@@ -118,6 +142,87 @@ func TestClientGetJSONSuccessful(t *testing.T) {
 	assert.Equal("Name", json.Name)
 }
 
+func TestClientNewRequestContext(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := NewClient("localhost", nil).NewRequestContext(ctx, "GET", "/", nil)
+
+	if assert.NoError(err) {
+		assert.Equal(ctx, req.Context())
+	}
+}
+
+func TestClientDoContextCancelled(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	resp, err := c.DoContext(ctx, "GET", "", nil)
+
+	assert.Nil(resp)
+	assert.Same(context.Canceled, err)
+}
+
+func TestClientGetJSONContextDeadlineExceeded(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(200)
+		fmt.Fprint(w, `{"name": `)
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	var json struct {
+		Name string
+	}
+
+	err := c.GetJSONContext(ctx, "", &json)
+
+	assert.Error(err)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestClientDoRequest(t *testing.T) {
+	assert := assert.New(t)
+
+	var accept string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	req, err := c.NewRequest("GET", "", nil)
+	assert.NoError(err)
+
+	req.Header.Set("Accept", "application/pdf")
+
+	resp, err := c.DoRequest(req)
+
+	if assert.NoError(err) {
+		resp.Body.Close()
+		assert.Equal("application/pdf", accept)
+	}
+}
+
 func TestClientHooks(t *testing.T) {
 	assert := assert.New(t)
 
@@ -164,3 +269,46 @@ func TestClientSearch(t *testing.T) {
 
 	assert.Same(c, c.Search().Client)
 }
+
+func TestClientOfficer(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewClient("localhost", nil)
+	o := c.Officer("abc123")
+
+	assert.Same(c, o.Client)
+	assert.Equal("abc123", o.ID)
+}
+
+func TestClientDisqualifiedOfficer(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewClient("localhost", nil)
+	d := c.DisqualifiedOfficer("abc123")
+
+	assert.Same(c, d.Client)
+	assert.Equal("abc123", d.ID)
+}
+
+func TestClientDocument(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewClient("localhost", nil)
+	d := c.Document("abc123")
+
+	assert.Same(c, d.Client)
+	assert.Equal("abc123", d.ID)
+	assert.Equal(DefaultDocumentHost, c.DocumentHost)
+}
+
+func TestClientStream(t *testing.T) {
+	assert := assert.New(t)
+
+	c := NewClient(DefaultHost, nil)
+	s := c.Stream(StreamCompanies)
+
+	assert.Same(c, s.Client)
+	assert.Equal(StreamCompanies, s.Stream)
+	assert.Equal(DefaultStreamHeartbeatTimeout, s.HeartbeatTimeout)
+	assert.Equal("https://"+DefaultStreamHost+"/companies", s.url())
+}
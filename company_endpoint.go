@@ -1,6 +1,11 @@
 package comphouse
 
-import "strings"
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
 
 // CompanyEndpoint is a struct that can be used to query the Companies House Public Data API
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference
@@ -9,6 +14,80 @@ type CompanyEndpoint struct {
 	Number CompanyNumber
 }
 
+// FilingHistoryParams are used to filter and paginate a company's filing
+// history
+type FilingHistoryParams struct {
+	Category     []string
+	ItemsPerPage int
+	StartIndex   int
+}
+
+// Encode converts FilingHistoryParams into an escaped string suitable for use
+// in query strings
+func (m FilingHistoryParams) Encode() string {
+	q := url.Values{}
+
+	if len(m.Category) > 0 {
+		q.Set("category", strings.Join(m.Category, ","))
+	}
+
+	if m.ItemsPerPage > 0 {
+		q.Set("items_per_page", strconv.Itoa(m.ItemsPerPage))
+	}
+
+	if m.StartIndex > 0 {
+		q.Set("start_index", strconv.Itoa(m.StartIndex))
+	}
+
+	return q.Encode()
+}
+
+// PSCParams are used to paginate a company's persons with significant
+// control and their statements
+type PSCParams struct {
+	ItemsPerPage int
+	StartIndex   int
+	RegisterView bool
+}
+
+// Encode converts PSCParams into an escaped string suitable for use in query
+// strings
+func (m PSCParams) Encode() string {
+	q := url.Values{}
+
+	if m.ItemsPerPage > 0 {
+		q.Set("items_per_page", strconv.Itoa(m.ItemsPerPage))
+	}
+
+	if m.StartIndex > 0 {
+		q.Set("start_index", strconv.Itoa(m.StartIndex))
+	}
+
+	if m.RegisterView {
+		q.Set("register_view", "true")
+	}
+
+	return q.Encode()
+}
+
+// helper function to append an encoded query string to a path
+func withQuery(path, query string) string {
+	if query == "" {
+		return path
+	}
+
+	return path + "?" + query
+}
+
+// helper function to build the query string for a page of results
+func startIndexQuery(startIndex int) string {
+	if startIndex <= 0 {
+		return ""
+	}
+
+	return "start_index=" + strconv.Itoa(startIndex)
+}
+
 // helper method to format a path for a company
 func (m *CompanyEndpoint) path(extra ...string) string {
 	p := "/company/" + m.Number.String()
@@ -23,9 +102,14 @@ func (m *CompanyEndpoint) path(extra ...string) string {
 // Get the basic company information
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/company-profile/company-profile
 func (m *CompanyEndpoint) Profile() (*CompanyProfile, error) {
+	return m.ProfileContext(context.Background())
+}
+
+// ProfileContext is the same as Profile but uses the provided context
+func (m *CompanyEndpoint) ProfileContext(ctx context.Context) (*CompanyProfile, error) {
 	c := &CompanyProfile{}
 
-	if err := m.Client.GetJSON(m.path(), c); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path(), c); err != nil {
 		return nil, err
 	}
 
@@ -35,9 +119,14 @@ func (m *CompanyEndpoint) Profile() (*CompanyProfile, error) {
 // Get the current address of a company
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/registered-office-address/registered-office-address
 func (m *CompanyEndpoint) RegisteredOfficeAddress() (*RegisteredOfficeAddress, error) {
+	return m.RegisteredOfficeAddressContext(context.Background())
+}
+
+// RegisteredOfficeAddressContext is the same as RegisteredOfficeAddress but uses the provided context
+func (m *CompanyEndpoint) RegisteredOfficeAddressContext(ctx context.Context) (*RegisteredOfficeAddress, error) {
 	a := &RegisteredOfficeAddress{}
 
-	if err := m.Client.GetJSON(m.path("registered-office-address"), a); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path("registered-office-address"), a); err != nil {
 		return nil, err
 	}
 
@@ -47,21 +136,56 @@ func (m *CompanyEndpoint) RegisteredOfficeAddress() (*RegisteredOfficeAddress, e
 // List of all company officers
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/officers/list
 func (m *CompanyEndpoint) Officers() (*OfficerList, error) {
+	return m.OfficersContext(context.Background())
+}
+
+// OfficersContext is the same as Officers but uses the provided context
+func (m *CompanyEndpoint) OfficersContext(ctx context.Context) (*OfficerList, error) {
 	o := &OfficerList{}
 
-	if err := m.Client.GetJSON(m.path("officers"), o); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path("officers"), o); err != nil {
 		return nil, err
 	}
 
 	return o, nil
 }
 
+// OfficersIter returns an iterator over every officer of the company,
+// fetching further pages as required
+func (m *CompanyEndpoint) OfficersIter() *OfficerListIterator {
+	return m.OfficersIterContext(context.Background())
+}
+
+// OfficersIterContext is the same as OfficersIter but uses the provided context
+func (m *CompanyEndpoint) OfficersIterContext(ctx context.Context) *OfficerListIterator {
+	it := &OfficerListIterator{}
+
+	it.pager = newPager(ctx, 0, func(ctx context.Context, start int) (int, int, error) {
+		o := &OfficerList{}
+
+		if err := m.Client.GetJSONContext(ctx, withQuery(m.path("officers"), startIndexQuery(start)), o); err != nil {
+			return 0, 0, err
+		}
+
+		it.items = o.Items
+
+		return len(o.Items), o.TotalResults, nil
+	})
+
+	return it
+}
+
 // Get details of an individual company officer appointment
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/officers/get-a-company-officer-appointment
 func (m *CompanyEndpoint) Appointments(appointmentId string) (*OfficerSummary, error) {
+	return m.AppointmentsContext(context.Background(), appointmentId)
+}
+
+// AppointmentsContext is the same as Appointments but uses the provided context
+func (m *CompanyEndpoint) AppointmentsContext(ctx context.Context, appointmentId string) (*OfficerSummary, error) {
 	o := &OfficerSummary{}
 
-	if err := m.Client.GetJSON(m.path("appointments", appointmentId), o); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path("appointments", appointmentId), o); err != nil {
 		return nil, err
 	}
 
@@ -71,9 +195,14 @@ func (m *CompanyEndpoint) Appointments(appointmentId string) (*OfficerSummary, e
 // Get the company registers information
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/registers/company-registers
 func (m *CompanyEndpoint) Registers() (*CompanyRegister, error) {
+	return m.RegistersContext(context.Background())
+}
+
+// RegistersContext is the same as Registers but uses the provided context
+func (m *CompanyEndpoint) RegistersContext(ctx context.Context) (*CompanyRegister, error) {
 	r := &CompanyRegister{}
 
-	if err := m.Client.GetJSON(m.path("registers"), r); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path("registers"), r); err != nil {
 		return nil, err
 	}
 
@@ -83,23 +212,330 @@ func (m *CompanyEndpoint) Registers() (*CompanyRegister, error) {
 // List of charges for a company
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/charges/list
 func (m *CompanyEndpoint) Charges() (*ChargeList, error) {
+	return m.ChargesContext(context.Background())
+}
+
+// ChargesContext is the same as Charges but uses the provided context
+func (m *CompanyEndpoint) ChargesContext(ctx context.Context) (*ChargeList, error) {
 	c := &ChargeList{}
 
-	if err := m.Client.GetJSON(m.path("charges"), c); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path("charges"), c); err != nil {
 		return nil, err
 	}
 
 	return c, nil
 }
 
+// ChargesIter returns an iterator over every charge registered against the
+// company, fetching further pages as required
+func (m *CompanyEndpoint) ChargesIter() *ChargeListIterator {
+	return m.ChargesIterContext(context.Background())
+}
+
+// ChargesIterContext is the same as ChargesIter but uses the provided context
+func (m *CompanyEndpoint) ChargesIterContext(ctx context.Context) *ChargeListIterator {
+	it := &ChargeListIterator{}
+
+	it.pager = newPager(ctx, 0, func(ctx context.Context, start int) (int, int, error) {
+		c := &ChargeList{}
+
+		if err := m.Client.GetJSONContext(ctx, withQuery(m.path("charges"), startIndexQuery(start)), c); err != nil {
+			return 0, 0, err
+		}
+
+		it.items = c.Items
+
+		return len(c.Items), c.TotalCount, nil
+	})
+
+	return it
+}
+
 // Individual charge information for company
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/charges/get
 func (m *CompanyEndpoint) Charge(chargeId string) (*ChargeDetails, error) {
+	return m.ChargeContext(context.Background(), chargeId)
+}
+
+// ChargeContext is the same as Charge but uses the provided context
+func (m *CompanyEndpoint) ChargeContext(ctx context.Context, chargeId string) (*ChargeDetails, error) {
 	c := &ChargeDetails{}
 
-	if err := m.Client.GetJSON(m.path("charges", chargeId), c); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path("charges", chargeId), c); err != nil {
 		return nil, err
 	}
 
 	return c, nil
 }
+
+// List of the company's filing history
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/filing-history/list
+func (m *CompanyEndpoint) FilingHistory(params FilingHistoryParams) (*FilingHistoryList, error) {
+	return m.FilingHistoryContext(context.Background(), params)
+}
+
+// FilingHistoryContext is the same as FilingHistory but uses the provided context
+func (m *CompanyEndpoint) FilingHistoryContext(ctx context.Context, params FilingHistoryParams) (*FilingHistoryList, error) {
+	f := &FilingHistoryList{}
+
+	if err := m.Client.GetJSONContext(ctx, withQuery(m.path("filing-history"), params.Encode()), f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Get a single item from the company's filing history
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/filing-history/get
+func (m *CompanyEndpoint) FilingHistoryItem(transactionID string) (*FilingHistoryItem, error) {
+	return m.FilingHistoryItemContext(context.Background(), transactionID)
+}
+
+// FilingHistoryItemContext is the same as FilingHistoryItem but uses the provided context
+func (m *CompanyEndpoint) FilingHistoryItemContext(ctx context.Context, transactionID string) (*FilingHistoryItem, error) {
+	f := &FilingHistoryItem{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("filing-history", transactionID), f); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// List of all persons with significant control
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/list
+func (m *CompanyEndpoint) PSCs(params PSCParams) (*PSCList, error) {
+	return m.PSCsContext(context.Background(), params)
+}
+
+// PSCsContext is the same as PSCs but uses the provided context
+func (m *CompanyEndpoint) PSCsContext(ctx context.Context, params PSCParams) (*PSCList, error) {
+	p := &PSCList{}
+
+	if err := m.Client.GetJSONContext(ctx, withQuery(m.path("persons-with-significant-control"), params.Encode()), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of an individual person with significant control
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-individual
+func (m *CompanyEndpoint) PSCIndividual(pscID string) (*PSCIndividual, error) {
+	return m.PSCIndividualContext(context.Background(), pscID)
+}
+
+// PSCIndividualContext is the same as PSCIndividual but uses the provided context
+func (m *CompanyEndpoint) PSCIndividualContext(ctx context.Context, pscID string) (*PSCIndividual, error) {
+	p := &PSCIndividual{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "individual", pscID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of an individual beneficial owner
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-individual-beneficial-owner
+func (m *CompanyEndpoint) PSCIndividualBeneficialOwner(pscID string) (*PSCIndividualBeneficialOwner, error) {
+	return m.PSCIndividualBeneficialOwnerContext(context.Background(), pscID)
+}
+
+// PSCIndividualBeneficialOwnerContext is the same as PSCIndividualBeneficialOwner but uses the provided context
+func (m *CompanyEndpoint) PSCIndividualBeneficialOwnerContext(ctx context.Context, pscID string) (*PSCIndividualBeneficialOwner, error) {
+	p := &PSCIndividualBeneficialOwner{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "individual-beneficial-owner", pscID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of a corporate entity person with significant control
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-corporate-entities
+func (m *CompanyEndpoint) PSCCorporateEntity(pscID string) (*PSCCorporateEntity, error) {
+	return m.PSCCorporateEntityContext(context.Background(), pscID)
+}
+
+// PSCCorporateEntityContext is the same as PSCCorporateEntity but uses the provided context
+func (m *CompanyEndpoint) PSCCorporateEntityContext(ctx context.Context, pscID string) (*PSCCorporateEntity, error) {
+	p := &PSCCorporateEntity{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "corporate-entity", pscID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of a corporate entity beneficial owner
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-corporate-entity-beneficial-owner
+func (m *CompanyEndpoint) PSCCorporateEntityBeneficialOwner(pscID string) (*PSCCorporateEntityBeneficialOwner, error) {
+	return m.PSCCorporateEntityBeneficialOwnerContext(context.Background(), pscID)
+}
+
+// PSCCorporateEntityBeneficialOwnerContext is the same as PSCCorporateEntityBeneficialOwner but uses the provided context
+func (m *CompanyEndpoint) PSCCorporateEntityBeneficialOwnerContext(ctx context.Context, pscID string) (*PSCCorporateEntityBeneficialOwner, error) {
+	p := &PSCCorporateEntityBeneficialOwner{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "corporate-entity-beneficial-owner", pscID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of a legal person with significant control
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-legal-persons
+func (m *CompanyEndpoint) PSCLegalPerson(pscID string) (*PSCLegalPerson, error) {
+	return m.PSCLegalPersonContext(context.Background(), pscID)
+}
+
+// PSCLegalPersonContext is the same as PSCLegalPerson but uses the provided context
+func (m *CompanyEndpoint) PSCLegalPersonContext(ctx context.Context, pscID string) (*PSCLegalPerson, error) {
+	p := &PSCLegalPerson{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "legal-person", pscID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of a legal person beneficial owner
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-legal-person-beneficial-owner
+func (m *CompanyEndpoint) PSCLegalPersonBeneficialOwner(pscID string) (*PSCLegalPersonBeneficialOwner, error) {
+	return m.PSCLegalPersonBeneficialOwnerContext(context.Background(), pscID)
+}
+
+// PSCLegalPersonBeneficialOwnerContext is the same as PSCLegalPersonBeneficialOwner but uses the provided context
+func (m *CompanyEndpoint) PSCLegalPersonBeneficialOwnerContext(ctx context.Context, pscID string) (*PSCLegalPersonBeneficialOwner, error) {
+	p := &PSCLegalPersonBeneficialOwner{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "legal-person-beneficial-owner", pscID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of a super secure person with significant control
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-super-secure-person
+func (m *CompanyEndpoint) PSCSuperSecure(superSecureID string) (*PSCSuperSecure, error) {
+	return m.PSCSuperSecureContext(context.Background(), superSecureID)
+}
+
+// PSCSuperSecureContext is the same as PSCSuperSecure but uses the provided context
+func (m *CompanyEndpoint) PSCSuperSecureContext(ctx context.Context, superSecureID string) (*PSCSuperSecure, error) {
+	p := &PSCSuperSecure{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "super-secure", superSecureID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of a super secure beneficial owner
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-super-secure-beneficial-owner
+func (m *CompanyEndpoint) PSCSuperSecureBeneficialOwner(superSecureID string) (*PSCSuperSecureBeneficialOwner, error) {
+	return m.PSCSuperSecureBeneficialOwnerContext(context.Background(), superSecureID)
+}
+
+// PSCSuperSecureBeneficialOwnerContext is the same as PSCSuperSecureBeneficialOwner but uses the provided context
+func (m *CompanyEndpoint) PSCSuperSecureBeneficialOwnerContext(ctx context.Context, superSecureID string) (*PSCSuperSecureBeneficialOwner, error) {
+	p := &PSCSuperSecureBeneficialOwner{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control", "super-secure-beneficial-owner", superSecureID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// List of all persons with significant control statements
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/list-statements
+func (m *CompanyEndpoint) PSCStatements(params PSCParams) (*PSCStatementList, error) {
+	return m.PSCStatementsContext(context.Background(), params)
+}
+
+// PSCStatementsContext is the same as PSCStatements but uses the provided context
+func (m *CompanyEndpoint) PSCStatementsContext(ctx context.Context, params PSCParams) (*PSCStatementList, error) {
+	p := &PSCStatementList{}
+
+	if err := m.Client.GetJSONContext(ctx, withQuery(m.path("persons-with-significant-control-statements"), params.Encode()), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get details of a person with significant control statement
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/persons-with-significant-control/get-statement
+func (m *CompanyEndpoint) PSCStatement(statementID string) (*PSCStatement, error) {
+	return m.PSCStatementContext(context.Background(), statementID)
+}
+
+// PSCStatementContext is the same as PSCStatement but uses the provided context
+func (m *CompanyEndpoint) PSCStatementContext(ctx context.Context, statementID string) (*PSCStatement, error) {
+	p := &PSCStatement{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("persons-with-significant-control-statements", statementID), p); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Get the company insolvency information
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/insolvency/company-insolvency
+func (m *CompanyEndpoint) Insolvency() (*CompanyInsolvency, error) {
+	return m.InsolvencyContext(context.Background())
+}
+
+// InsolvencyContext is the same as Insolvency but uses the provided context
+func (m *CompanyEndpoint) InsolvencyContext(ctx context.Context) (*CompanyInsolvency, error) {
+	i := &CompanyInsolvency{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("insolvency"), i); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// Get the company exemptions information
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/exemptions/company-exemptions
+func (m *CompanyEndpoint) Exemptions() (*CompanyExemptions, error) {
+	return m.ExemptionsContext(context.Background())
+}
+
+// ExemptionsContext is the same as Exemptions but uses the provided context
+func (m *CompanyEndpoint) ExemptionsContext(ctx context.Context) (*CompanyExemptions, error) {
+	e := &CompanyExemptions{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("exemptions"), e); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// List of the company's UK establishments
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/uk-establishments/company-uk-establishments
+func (m *CompanyEndpoint) UKEstablishments() (*CompanyUKEstablishments, error) {
+	return m.UKEstablishmentsContext(context.Background())
+}
+
+// UKEstablishmentsContext is the same as UKEstablishments but uses the provided context
+func (m *CompanyEndpoint) UKEstablishmentsContext(ctx context.Context) (*CompanyUKEstablishments, error) {
+	u := &CompanyUKEstablishments{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("uk-establishments"), u); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
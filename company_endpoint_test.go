@@ -1,12 +1,248 @@
 package comphouse
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+func TestFilingHistoryParamsEncode(t *testing.T) {
+	type test struct {
+		inp FilingHistoryParams
+		exp string
+	}
+
+	tests := []test{
+		{FilingHistoryParams{}, ""},
+		{FilingHistoryParams{Category: []string{"accounts"}}, "category=accounts"},
+		{FilingHistoryParams{Category: []string{"accounts", "officers"}, ItemsPerPage: 25}, "category=accounts%2Cofficers&items_per_page=25"},
+		{FilingHistoryParams{ItemsPerPage: 50, StartIndex: 100}, "items_per_page=50&start_index=100"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(test.exp, test.inp.Encode())
+		})
+	}
+}
+
+func TestCompanyEndpointFilingHistory(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"total_count": 1,
+			"items": [{
+				"transaction_id": "MzAwMDAwMDAwMGFkaXF6a2N4",
+				"category": "accounts",
+				"description_values": {"made_up_date": "2020-12-31"},
+				"annotations": [{"annotation": "Clarification", "date": "2021-01-01"}],
+				"resolutions": [{"category": "incorporation", "type": "RES15"}],
+				"links": {"document_metadata": "https://document-api.company-information.service.gov.uk/document/abc"}
+			}]
+		}`)
+	})
+
+	defer ts.Close()
+
+	f, err := c.Company(EnglishCompanyNo(1)).FilingHistory(FilingHistoryParams{Category: []string{"accounts"}, ItemsPerPage: 10})
+
+	if assert.NoError(err) {
+		assert.Equal("/company/00000001/filing-history?category=accounts&items_per_page=10", uri)
+		assert.Equal(1, f.TotalCount)
+		assert.Equal("MzAwMDAwMDAwMGFkaXF6a2N4", f.Items[0].TransactionID)
+		assert.Equal("2020-12-31", f.Items[0].DescriptionValues["made_up_date"])
+		assert.Equal("Clarification", f.Items[0].Annotations[0].Annotation)
+		assert.Equal("RES15", f.Items[0].Resolutions[0].Type)
+		assert.Equal("https://document-api.company-information.service.gov.uk/document/abc", f.Items[0].Links.DocumentMetadata)
+	}
+
+	_, err = c.Company(EnglishCompanyNo(1)).FilingHistoryItem("MzAwMDAwMDAwMGFkaXF6a2N4")
+
+	if assert.NoError(err) {
+		assert.Equal("/company/00000001/filing-history/MzAwMDAwMDAwMGFkaXF6a2N4", uri)
+	}
+}
+
+func TestPSCParamsEncode(t *testing.T) {
+	type test struct {
+		inp PSCParams
+		exp string
+	}
+
+	tests := []test{
+		{PSCParams{}, ""},
+		{PSCParams{ItemsPerPage: 25}, "items_per_page=25"},
+		{PSCParams{StartIndex: 50, RegisterView: true}, "register_view=true&start_index=50"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(test.exp, test.inp.Encode())
+		})
+	}
+}
+
+func TestCompanyEndpointPSCPaths(t *testing.T) {
+	type test struct {
+		exp string
+		f   func(*CompanyEndpoint) error
+	}
+
+	tests := []test{
+		{
+			"/company/00000001/persons-with-significant-control?items_per_page=10&register_view=true",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCs(PSCParams{ItemsPerPage: 10, RegisterView: true})
+				return err
+			},
+		},
+		{
+			"/company/00000001/persons-with-significant-control/individual/abc",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCIndividual("abc")
+				return err
+			},
+		},
+		{
+			"/company/00000001/persons-with-significant-control/corporate-entity-beneficial-owner/abc",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCCorporateEntityBeneficialOwner("abc")
+				return err
+			},
+		},
+		{
+			"/company/00000001/persons-with-significant-control/super-secure/abc",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCSuperSecure("abc")
+				return err
+			},
+		},
+		{
+			"/company/00000001/persons-with-significant-control-statements?start_index=5",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCStatements(PSCParams{StartIndex: 5})
+				return err
+			},
+		},
+		{
+			"/company/00000001/persons-with-significant-control-statements/abc",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCStatement("abc")
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+
+			var uri string
+
+			ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+				uri = r.URL.RequestURI()
+				fmt.Fprint(w, "{}")
+			})
+
+			defer ts.Close()
+
+			assert.NoError(test.f(c.Company(EnglishCompanyNo(1))))
+			assert.Equal(test.exp, uri)
+		})
+	}
+}
+
+func TestCompanyEndpointInsolvency(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"status": ["liquidation"],
+			"cases": [{
+				"type": "creditors-voluntary-liquidation",
+				"number": "1",
+				"dates": [{"type": "wound-up-on", "date": "2020-01-01"}],
+				"practitioners": [{"name": "A Practitioner", "role": "practitioner", "address": {"postal_code": "AB1 2CD"}}]
+			}]
+		}`)
+	})
+
+	defer ts.Close()
+
+	i, err := c.Company(EnglishCompanyNo(1)).Insolvency()
+
+	if assert.NoError(err) {
+		assert.Equal("/company/00000001/insolvency", uri)
+		assert.Equal([]string{"liquidation"}, i.Status)
+		assert.Equal("creditors-voluntary-liquidation", i.Cases[0].Type)
+		assert.Equal("wound-up-on", i.Cases[0].Dates[0].Type)
+		assert.Equal("AB1 2CD", i.Cases[0].Practitioners[0].Address.PostalCode)
+	}
+}
+
+func TestCompanyEndpointExemptions(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"exemptions": {
+				"psc_exempt_as_trading_on_regulated_market": {
+					"exemption_type": "psc-exempt-as-trading-on-regulated-market",
+					"items": [{"exempt_from": "2016-06-30"}]
+				}
+			}
+		}`)
+	})
+
+	defer ts.Close()
+
+	e, err := c.Company(EnglishCompanyNo(1)).Exemptions()
+
+	if assert.NoError(err) {
+		exemption := e.Exemptions.PscExemptAsTradingOnRegulatedMarket
+
+		assert.Equal("/company/00000001/exemptions", uri)
+		assert.Equal("psc-exempt-as-trading-on-regulated-market", exemption.ExemptionType)
+		assert.Equal("2016-06-30", exemption.Items[0].ExemptFrom)
+	}
+}
+
+func TestCompanyEndpointUKEstablishments(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{"items": [{"company_number": "BR000001", "company_name": "Branch", "locality": "London"}]}`)
+	})
+
+	defer ts.Close()
+
+	u, err := c.Company(EnglishCompanyNo(1)).UKEstablishments()
+
+	if assert.NoError(err) {
+		assert.Equal("/company/00000001/uk-establishments", uri)
+		assert.Equal("BR000001", u.Items[0].CompanyNumber)
+		assert.Equal("London", u.Items[0].Locality)
+	}
+}
+
 func checkCompanyEndpointHandlesError(t *testing.T, f func(*CompanyEndpoint) error) {
 	assert := assert.New(t)
 
@@ -76,6 +312,118 @@ func TestCompanyEndpointHandlesErrors(t *testing.T) {
 				return err
 			},
 		},
+		{
+			"CompanyEndpoint.FilingHistory",
+			func(c *CompanyEndpoint) error {
+				_, err := c.FilingHistory(FilingHistoryParams{})
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.FilingHistoryItem",
+			func(c *CompanyEndpoint) error {
+				_, err := c.FilingHistoryItem("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCs",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCs(PSCParams{})
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCIndividual",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCIndividual("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCIndividualBeneficialOwner",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCIndividualBeneficialOwner("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCCorporateEntity",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCCorporateEntity("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCCorporateEntityBeneficialOwner",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCCorporateEntityBeneficialOwner("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCLegalPerson",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCLegalPerson("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCLegalPersonBeneficialOwner",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCLegalPersonBeneficialOwner("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCSuperSecure",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCSuperSecure("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCSuperSecureBeneficialOwner",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCSuperSecureBeneficialOwner("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCStatements",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCStatements(PSCParams{})
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCStatement",
+			func(c *CompanyEndpoint) error {
+				_, err := c.PSCStatement("")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.Insolvency",
+			func(c *CompanyEndpoint) error {
+				_, err := c.Insolvency()
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.Exemptions",
+			func(c *CompanyEndpoint) error {
+				_, err := c.Exemptions()
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.UKEstablishments",
+			func(c *CompanyEndpoint) error {
+				_, err := c.UKEstablishments()
+				return err
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -134,6 +482,102 @@ func TestCompanyEndpointDecodesResponses(t *testing.T) {
 				return c.Charge("")
 			},
 		},
+		{
+			"CompanyEndpoint.FilingHistory",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.FilingHistory(FilingHistoryParams{})
+			},
+		},
+		{
+			"CompanyEndpoint.FilingHistoryItem",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.FilingHistoryItem("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCs",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCs(PSCParams{})
+			},
+		},
+		{
+			"CompanyEndpoint.PSCIndividual",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCIndividual("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCIndividualBeneficialOwner",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCIndividualBeneficialOwner("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCCorporateEntity",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCCorporateEntity("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCCorporateEntityBeneficialOwner",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCCorporateEntityBeneficialOwner("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCLegalPerson",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCLegalPerson("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCLegalPersonBeneficialOwner",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCLegalPersonBeneficialOwner("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCSuperSecure",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCSuperSecure("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCSuperSecureBeneficialOwner",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCSuperSecureBeneficialOwner("")
+			},
+		},
+		{
+			"CompanyEndpoint.PSCStatements",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCStatements(PSCParams{})
+			},
+		},
+		{
+			"CompanyEndpoint.PSCStatement",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.PSCStatement("")
+			},
+		},
+		{
+			"CompanyEndpoint.Insolvency",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.Insolvency()
+			},
+		},
+		{
+			"CompanyEndpoint.Exemptions",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.Exemptions()
+			},
+		},
+		{
+			"CompanyEndpoint.UKEstablishments",
+			func(c *CompanyEndpoint) (interface{}, error) {
+				return c.UKEstablishments()
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -151,3 +595,191 @@ func TestCompanyEndpointDecodesResponses(t *testing.T) {
 		})
 	}
 }
+
+func TestCompanyEndpointRespectsContext(t *testing.T) {
+	type test struct {
+		name string
+		f    func(context.Context, *CompanyEndpoint) error
+	}
+
+	tests := []test{
+		{
+			"CompanyEndpoint.ProfileContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.ProfileContext(ctx)
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.RegisteredOfficeAddressContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.RegisteredOfficeAddressContext(ctx)
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.OfficersContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.OfficersContext(ctx)
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.AppointmentsContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.AppointmentsContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.RegistersContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.RegistersContext(ctx)
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.ChargesContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.ChargesContext(ctx)
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.ChargeContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.ChargeContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.FilingHistoryContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.FilingHistoryContext(ctx, FilingHistoryParams{})
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.FilingHistoryItemContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.FilingHistoryItemContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCsContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCsContext(ctx, PSCParams{})
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCIndividualContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCIndividualContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCIndividualBeneficialOwnerContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCIndividualBeneficialOwnerContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCCorporateEntityContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCCorporateEntityContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCCorporateEntityBeneficialOwnerContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCCorporateEntityBeneficialOwnerContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCLegalPersonContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCLegalPersonContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCLegalPersonBeneficialOwnerContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCLegalPersonBeneficialOwnerContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCSuperSecureContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCSuperSecureContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCSuperSecureBeneficialOwnerContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCSuperSecureBeneficialOwnerContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCStatementsContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCStatementsContext(ctx, PSCParams{})
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.PSCStatementContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.PSCStatementContext(ctx, "")
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.InsolvencyContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.InsolvencyContext(ctx)
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.ExemptionsContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.ExemptionsContext(ctx)
+				return err
+			},
+		},
+		{
+			"CompanyEndpoint.UKEstablishmentsContext",
+			func(ctx context.Context, c *CompanyEndpoint) error {
+				_, err := c.UKEstablishmentsContext(ctx)
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ts, c := createTestServerWithResponse(map[string]string{"company_name": "Company Name"})
+
+			defer ts.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := test.f(ctx, c.Company(EnglishCompanyNo(1)))
+
+			assert.Same(context.Canceled, err)
+		})
+	}
+}
@@ -1,39 +1,235 @@
 package comphouse
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 )
 
+// CompanyNumberLength is the number of characters in every company number,
+// including its prefix
+const CompanyNumberLength = 8
+
 // CompanyNumber is an interface to convert a value into a valid company number
 type CompanyNumber interface {
 	fmt.Stringer
 	Next() CompanyNumber
 }
 
+// CompanyNumberPrefixes are the prefixes Companies House uses to denote the
+// kind of entity a company number was issued to. Companies registered in
+// England and Wales don't have a prefix. UK establishments of overseas
+// companies are numbered separately, with a "BR" prefix, and are not included
+var CompanyNumberPrefixes = []string{
+	"AC", "CE", "CN", "CS", "FC", "GE", "GN", "GS", "IC", "IP", "LP", "NA",
+	"NC", "NF", "NI", "NL", "NP", "NR", "NZ", "OC", "OE", "R", "RC", "RS",
+	"SA", "SC", "SE", "SF", "SG", "SI", "SL", "SO", "SP", "SR", "SZ", "ZC",
+}
+
+// EntityKind is the kind of entity a company number was issued to, as
+// implied by its prefix
+type EntityKind string
+
+// Entity kinds denoted by company number prefixes
+const (
+	EntityKindCompany                            EntityKind = "company"
+	EntityKindLimitedLiabilityPartnership        EntityKind = "limited-liability-partnership"
+	EntityKindLimitedPartnership                 EntityKind = "limited-partnership"
+	EntityKindScottishPartnership                EntityKind = "scottish-partnership"
+	EntityKindOverseasCompany                    EntityKind = "overseas-company"
+	EntityKindOverseasEntity                     EntityKind = "registered-overseas-entity"
+	EntityKindRegisteredSociety                  EntityKind = "registered-society"
+	EntityKindCharitableIncorporatedOrganisation EntityKind = "charitable-incorporated-organisation"
+	EntityKindEuropeanEconomicInterestGrouping   EntityKind = "european-economic-interest-grouping"
+	EntityKindEuropeanPublicCompany              EntityKind = "european-public-company"
+	EntityKindInvestmentCompany                  EntityKind = "investment-company-with-variable-capital"
+	EntityKindRoyalCharter                       EntityKind = "royal-charter"
+	EntityKindAssuranceCompany                   EntityKind = "assurance-company"
+	EntityKindUnregisteredCompany                EntityKind = "unregistered-company"
+	EntityKindUnknown                            EntityKind = "unknown"
+)
+
+// CompanyNumberInfo describes what a company number denotes
+type CompanyNumberInfo struct {
+	Prefix       string
+	Jurisdiction Jurisdiction
+	EntityKind   EntityKind
+}
+
+// companyNumberInfo maps each prefix to the jurisdiction and kind of entity
+// it denotes
+var companyNumberInfo = map[string]CompanyNumberInfo{
+	"":   {"", JurisdictionEnglandWales, EntityKindCompany},
+	"SC": {"SC", JurisdictionScotland, EntityKindCompany},
+	"NI": {"NI", JurisdictionNorthernIreland, EntityKindCompany},
+	"R":  {"R", JurisdictionNorthernIreland, EntityKindCompany},
+	"OC": {"OC", JurisdictionEnglandWales, EntityKindLimitedLiabilityPartnership},
+	"SO": {"SO", JurisdictionScotland, EntityKindLimitedLiabilityPartnership},
+	"NC": {"NC", JurisdictionNorthernIreland, EntityKindLimitedLiabilityPartnership},
+	"LP": {"LP", JurisdictionEnglandWales, EntityKindLimitedPartnership},
+	"SL": {"SL", JurisdictionScotland, EntityKindLimitedPartnership},
+	"NL": {"NL", JurisdictionNorthernIreland, EntityKindLimitedPartnership},
+	"SG": {"SG", JurisdictionScotland, EntityKindScottishPartnership},
+	"FC": {"FC", JurisdictionEnglandWales, EntityKindOverseasCompany},
+	"SF": {"SF", JurisdictionScotland, EntityKindOverseasCompany},
+	"NF": {"NF", JurisdictionNorthernIreland, EntityKindOverseasCompany},
+	"OE": {"OE", JurisdictionUnitedKingdom, EntityKindOverseasEntity},
+	"IP": {"IP", JurisdictionEnglandWales, EntityKindRegisteredSociety},
+	"RS": {"RS", JurisdictionEnglandWales, EntityKindRegisteredSociety},
+	"SP": {"SP", JurisdictionScotland, EntityKindRegisteredSociety},
+	"NP": {"NP", JurisdictionNorthernIreland, EntityKindRegisteredSociety},
+	"CE": {"CE", JurisdictionEnglandWales, EntityKindCharitableIncorporatedOrganisation},
+	"CS": {"CS", JurisdictionScotland, EntityKindCharitableIncorporatedOrganisation},
+	"CN": {"CN", JurisdictionNorthernIreland, EntityKindCharitableIncorporatedOrganisation},
+	"GE": {"GE", JurisdictionEnglandWales, EntityKindEuropeanEconomicInterestGrouping},
+	"GS": {"GS", JurisdictionScotland, EntityKindEuropeanEconomicInterestGrouping},
+	"GN": {"GN", JurisdictionNorthernIreland, EntityKindEuropeanEconomicInterestGrouping},
+	"SE": {"SE", JurisdictionEnglandWales, EntityKindEuropeanPublicCompany},
+	"IC": {"IC", JurisdictionEnglandWales, EntityKindInvestmentCompany},
+	"SI": {"SI", JurisdictionScotland, EntityKindInvestmentCompany},
+	"RC": {"RC", JurisdictionEnglandWales, EntityKindRoyalCharter},
+	"SR": {"SR", JurisdictionScotland, EntityKindRoyalCharter},
+	"NR": {"NR", JurisdictionNorthernIreland, EntityKindRoyalCharter},
+	"AC": {"AC", JurisdictionEnglandWales, EntityKindAssuranceCompany},
+	"SA": {"SA", JurisdictionScotland, EntityKindAssuranceCompany},
+	"NA": {"NA", JurisdictionNorthernIreland, EntityKindAssuranceCompany},
+	"ZC": {"ZC", JurisdictionEnglandWales, EntityKindUnregisteredCompany},
+	"SZ": {"SZ", JurisdictionScotland, EntityKindUnregisteredCompany},
+	"NZ": {"NZ", JurisdictionNorthernIreland, EntityKindUnregisteredCompany},
+}
+
+// DescribeCompanyNumber reports the prefix of a company number along with
+// the jurisdiction and kind of entity it denotes. It works with any
+// CompanyNumber implementation by inspecting its string form. The jurisdiction
+// is empty and the kind is EntityKindUnknown for unrecognised prefixes and
+// for numbers that aren't valid, including missing or unparsed CompanyNo
+// values, so they're never mistaken for companies registered in England
+func DescribeCompanyNumber(companyNo CompanyNumber) CompanyNumberInfo {
+	s := companyNo.String()
+	prefix, digits := splitCompanyNumber(s)
+
+	unknown := CompanyNumberInfo{Prefix: prefix, EntityKind: EntityKindUnknown}
+
+	if c, ok := companyNo.(CompanyNo); ok && !c.Valid() {
+		return unknown
+	}
+
+	if len(digits) != CompanyNumberLength-len(prefix) {
+		return unknown
+	}
+
+	if _, err := CompanyNumberFromString(s); err != nil {
+		return unknown
+	}
+
+	if info, ok := companyNumberInfo[prefix]; ok {
+		return info
+	}
+
+	return unknown
+}
+
+// splitCompanyNumber splits an upper case company number into its letter
+// prefix and the remainder
+func splitCompanyNumber(s string) (string, string) {
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return r < 'A' || r > 'Z'
+	})
+
+	if i < 0 {
+		i = len(s)
+	}
+
+	return s[:i], s[i:]
+}
+
+// CompanyNumberError is returned when a string can't be parsed as a
+// CompanyNumber. It matches ErrInvalidCompanyNo when using errors.Is
+type CompanyNumberError struct {
+	Input  string
+	Reason string
+	Err    error
+}
+
+// Error satisfies the error interface
+func (m *CompanyNumberError) Error() string {
+	msg := fmt.Sprintf("%s %q: %s", ErrInvalidCompanyNo, m.Input, m.Reason)
+
+	if m.Err != nil {
+		msg += ": " + m.Err.Error()
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying error, if any
+func (m *CompanyNumberError) Unwrap() error {
+	return m.Err
+}
+
+// Is reports whether target is ErrInvalidCompanyNo
+func (m *CompanyNumberError) Is(target error) bool {
+	return target == ErrInvalidCompanyNo
+}
+
 // CompanyNumberFromString creates a new CompanyNumber from the provided string
-// It returns an error if the input cannot be converted to a CompanyNumber
+// It returns a *CompanyNumberError if the input has an unknown prefix, isn't
+// numeric after its prefix, has too many digits or is zero. Numbers with
+// fewer digits than required are padded with zeros. Numbers without a prefix
+// are returned as an EnglishCompanyNo, numbers prefixed with SC as a
+// ScottishCompanyNo and all others as a PrefixedCompanyNo
 func CompanyNumberFromString(s string) (CompanyNumber, error) {
-	s = strings.ToUpper(s)
+	input := s
+	s = strings.ToUpper(strings.TrimSpace(s))
 
-	f := func(num uint64) CompanyNumber {
-		return EnglishCompanyNo(num)
+	invalid := func(reason string, err error) (CompanyNumber, error) {
+		return nil, &CompanyNumberError{Input: input, Reason: reason, Err: err}
 	}
 
-	if strings.HasPrefix(s, "SC") {
-		f = func(num uint64) CompanyNumber {
-			return ScottishCompanyNo(num)
-		}
-		s = s[2:]
+	if s == "" {
+		return invalid("empty", nil)
+	}
+
+	prefix, digits := splitCompanyNumber(s)
+
+	if prefix != "" && !isCompanyNumberPrefix(prefix) {
+		return invalid(fmt.Sprintf("unknown prefix %q", prefix), nil)
+	}
+
+	if digits == "" {
+		return invalid("missing number", strconv.ErrSyntax)
+	}
+
+	if width := CompanyNumberLength - len(prefix); len(digits) > width {
+		return invalid(fmt.Sprintf("expected at most %d digits", width), nil)
 	}
 
-	num, err := strconv.ParseUint(s, 10, 64)
+	num, err := strconv.ParseUint(digits, 10, 32)
 	if err != nil {
-		return nil, err
+		return invalid("invalid number", strconv.ErrSyntax)
 	}
 
-	return f(num), nil
+	if num == 0 {
+		return invalid("number must be greater than zero", nil)
+	}
+
+	switch prefix {
+	case "":
+		return EnglishCompanyNo(num), nil
+	case "SC":
+		return ScottishCompanyNo(num), nil
+	}
+
+	return PrefixedCompanyNo{Prefix: prefix, Number: uint(num)}, nil
+}
+
+// isCompanyNumberPrefix reports whether prefix is a known company number
+// prefix
+func isCompanyNumberPrefix(prefix string) bool {
+	_, ok := companyNumberInfo[prefix]
+	return ok
 }
 
 // EnglishCompanyNo is a CompanyNumber implementation for English companies
@@ -44,8 +240,13 @@ func (m EnglishCompanyNo) String() string {
 	return fmt.Sprintf("%08d", m)
 }
 
-// Next satisfies the CompanyNumber interface
+// Next satisfies the CompanyNumber interface. It returns the number unchanged
+// once the largest number that fits in CompanyNumberLength is reached
 func (m EnglishCompanyNo) Next() CompanyNumber {
+	if uint(m) >= maxCompanyNumber("") {
+		return m
+	}
+
 	return m + 1
 }
 
@@ -57,7 +258,333 @@ func (m ScottishCompanyNo) String() string {
 	return fmt.Sprintf("SC%06d", m)
 }
 
-// Next satisfies the CompanyNumber interface
+// Next satisfies the CompanyNumber interface. It returns the number unchanged
+// once the largest number that fits after the SC prefix is reached
 func (m ScottishCompanyNo) Next() CompanyNumber {
+	if uint(m) >= maxCompanyNumber("SC") {
+		return m
+	}
+
 	return m + 1
 }
+
+// PrefixedCompanyNo is a CompanyNumber implementation for numbers with any of
+// the CompanyNumberPrefixes, such as OC for limited liability partnerships or
+// NI for Northern Irish companies
+type PrefixedCompanyNo struct {
+	Prefix string
+	Number uint
+}
+
+// String satisfies the CompanyNumber interface
+func (m PrefixedCompanyNo) String() string {
+	return fmt.Sprintf("%s%0*d", m.Prefix, CompanyNumberLength-len(m.Prefix), m.Number)
+}
+
+// Next satisfies the CompanyNumber interface. It returns the number unchanged
+// once the largest number that fits after the prefix is reached
+func (m PrefixedCompanyNo) Next() CompanyNumber {
+	if m.Number >= maxCompanyNumber(m.Prefix) {
+		return m
+	}
+
+	return PrefixedCompanyNo{Prefix: m.Prefix, Number: m.Number + 1}
+}
+
+// maxCompanyNumber returns the largest number that fits after a prefix
+// without exceeding CompanyNumberLength
+func maxCompanyNumber(prefix string) uint {
+	max := uint(1)
+
+	for i := len(prefix); i < CompanyNumberLength; i++ {
+		max *= 10
+	}
+
+	return max - 1
+}
+
+// CompanyNo is a company number that can be used as a value. It implements
+// CompanyNumber so it can be passed directly to Client.Company and can be
+// marshalled to and from text, JSON and SQL columns. The zero value represents
+// a missing company number and is encoded as an empty string, JSON null or SQL
+// NULL respectively
+//
+// Decoding is deliberately lenient as the API's data is authoritative: a
+// number that CompanyNumberFromString doesn't recognise is kept as is rather
+// than failing the whole response. Valid and Err report whether it was parsed.
+// Use ParseCompanyNo to strictly validate user input
+type CompanyNo struct {
+	number CompanyNumber
+	raw    string
+	err    error
+}
+
+// NewCompanyNo creates a CompanyNo from an existing CompanyNumber
+func NewCompanyNo(companyNo CompanyNumber) CompanyNo {
+	if c, ok := companyNo.(CompanyNo); ok {
+		return c
+	}
+
+	return CompanyNo{number: companyNo}
+}
+
+// ParseCompanyNo parses a company number using CompanyNumberFromString
+func ParseCompanyNo(s string) (CompanyNo, error) {
+	number, err := CompanyNumberFromString(s)
+	if err != nil {
+		return CompanyNo{}, err
+	}
+
+	return CompanyNo{number: number}, nil
+}
+
+// MustParseCompanyNo is like ParseCompanyNo but panics if s is invalid. It
+// simplifies declaring company numbers known to be valid
+func MustParseCompanyNo(s string) CompanyNo {
+	c, err := ParseCompanyNo(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+// decodeCompanyNo parses a decoded company number, keeping the raw string
+// along with the parse error if it isn't recognised
+func decodeCompanyNo(s string) CompanyNo {
+	if s == "" {
+		return CompanyNo{}
+	}
+
+	number, err := CompanyNumberFromString(s)
+	if err != nil {
+		return CompanyNo{raw: s, err: err}
+	}
+
+	return CompanyNo{number: number}
+}
+
+// IsZero reports whether the CompanyNo is missing
+func (m CompanyNo) IsZero() bool {
+	return m.number == nil && m.raw == ""
+}
+
+// Valid reports whether the CompanyNo holds a recognised company number
+func (m CompanyNo) Valid() bool {
+	return m.number != nil
+}
+
+// Err returns the error encountered parsing a decoded company number, or nil
+// if it was recognised or is missing
+func (m CompanyNo) Err() error {
+	return m.err
+}
+
+// Number returns the underlying CompanyNumber, which is nil for the zero
+// value and unrecognised company numbers
+func (m CompanyNo) Number() CompanyNumber {
+	return m.number
+}
+
+// String satisfies the CompanyNumber interface. It returns an empty string
+// for the zero value and the decoded string for unrecognised company numbers
+func (m CompanyNo) String() string {
+	if m.number == nil {
+		return m.raw
+	}
+
+	return m.number.String()
+}
+
+// Next satisfies the CompanyNumber interface. It returns the CompanyNo
+// unchanged when called on the zero value or an unrecognised company number
+func (m CompanyNo) Next() CompanyNumber {
+	if m.number == nil {
+		return m
+	}
+
+	return CompanyNo{number: m.number.Next()}
+}
+
+// MarshalText satisfies the encoding.TextMarshaler interface
+func (m CompanyNo) MarshalText() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText satisfies the encoding.TextUnmarshaler interface. Empty text
+// results in the zero value
+func (m *CompanyNo) UnmarshalText(text []byte) error {
+	*m = decodeCompanyNo(string(text))
+	return nil
+}
+
+// MarshalJSON satisfies the json.Marshaler interface
+func (m CompanyNo) MarshalJSON() ([]byte, error) {
+	if m.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(m.String())
+}
+
+// UnmarshalJSON satisfies the json.Unmarshaler interface. Null and empty
+// strings result in the zero value
+func (m *CompanyNo) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*m = CompanyNo{}
+		return nil
+	}
+
+	var s string
+
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return m.UnmarshalText([]byte(s))
+}
+
+// Scan satisfies the sql.Scanner interface
+func (m *CompanyNo) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*m = CompanyNo{}
+		return nil
+	case string:
+		return m.UnmarshalText([]byte(v))
+	case []byte:
+		return m.UnmarshalText(v)
+	}
+
+	return fmt.Errorf("%w: cannot scan %T", ErrInvalidCompanyNo, src)
+}
+
+// Value satisfies the driver.Valuer interface
+func (m CompanyNo) Value() (driver.Value, error) {
+	if m.IsZero() {
+		return nil, nil
+	}
+
+	return m.String(), nil
+}
+
+// CompanyNumberRange is an inclusive range of company numbers sharing the
+// same prefix
+type CompanyNumberRange struct {
+	From CompanyNumber
+	To   CompanyNumber
+}
+
+// NewCompanyNumberRange creates a new CompanyNumberRange. It returns an error
+// wrapping ErrInvalidRange if the numbers have different prefixes or from is
+// after to
+func NewCompanyNumberRange(from, to CompanyNumber) (CompanyNumberRange, error) {
+	r := CompanyNumberRange{From: from, To: to}
+
+	if err := r.Validate(); err != nil {
+		return CompanyNumberRange{}, err
+	}
+
+	return r, nil
+}
+
+// Validate checks the range's bounds share a prefix and are in order
+func (m CompanyNumberRange) Validate() error {
+	if m.From == nil || m.To == nil {
+		return fmt.Errorf("%w: missing bound", ErrInvalidRange)
+	}
+
+	fromPrefix, from, err := companyNumberParts(m.From)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRange, err)
+	}
+
+	toPrefix, to, err := companyNumberParts(m.To)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRange, err)
+	}
+
+	if fromPrefix != toPrefix {
+		return fmt.Errorf("%w: %s and %s have different prefixes", ErrInvalidRange, m.From, m.To)
+	}
+
+	if from > to {
+		return fmt.Errorf("%w: %s is after %s", ErrInvalidRange, m.From, m.To)
+	}
+
+	return nil
+}
+
+// Len returns the number of company numbers in the range, or zero if the
+// range is invalid
+func (m CompanyNumberRange) Len() int {
+	if m.Validate() != nil {
+		return 0
+	}
+
+	_, from, _ := companyNumberParts(m.From)
+	_, to, _ := companyNumberParts(m.To)
+
+	return int(to-from) + 1
+}
+
+// Contains reports whether a company number is in the range
+func (m CompanyNumberRange) Contains(companyNo CompanyNumber) bool {
+	if m.Validate() != nil {
+		return false
+	}
+
+	prefix, num, err := companyNumberParts(companyNo)
+	if err != nil {
+		return false
+	}
+
+	fromPrefix, from, _ := companyNumberParts(m.From)
+	_, to, _ := companyNumberParts(m.To)
+
+	return prefix == fromPrefix && num >= from && num <= to
+}
+
+// After returns the part of the range following a company number, which is
+// the whole range if the number comes before it and an empty range, with a Len
+// of zero, if the number is at or after its end
+func (m CompanyNumberRange) After(companyNo CompanyNumber) CompanyNumberRange {
+	prefix, num, err := companyNumberParts(companyNo)
+	if err != nil {
+		return m
+	}
+
+	fromPrefix, from, err := companyNumberParts(m.From)
+	if err != nil || prefix != fromPrefix || num < from {
+		return m
+	}
+
+	return CompanyNumberRange{From: companyNo.Next(), To: m.To}
+}
+
+// Each calls f with every company number in the range in order until f
+// returns false
+func (m CompanyNumberRange) Each(f func(CompanyNumber) bool) {
+	n := m.From
+
+	for i := m.Len(); i > 0; i-- {
+		if !f(n) {
+			return
+		}
+
+		n = n.Next()
+	}
+}
+
+// companyNumberParts splits a company number into its prefix and numeric
+// value
+func companyNumberParts(companyNo CompanyNumber) (string, uint64, error) {
+	prefix, digits := splitCompanyNumber(companyNo.String())
+
+	num, err := strconv.ParseUint(digits, 10, 64)
+	if err != nil || num > uint64(maxCompanyNumber(prefix)) {
+		return "", 0, fmt.Errorf("%w %q", ErrInvalidCompanyNo, companyNo.String())
+	}
+
+	return prefix, num, nil
+}
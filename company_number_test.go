@@ -1,6 +1,9 @@
 package comphouse
 
 import (
+	"encoding/json"
+	"net/http"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,6 +36,18 @@ func TestCompanyNumberFromString(t *testing.T) {
 		{"10010010", EnglishCompanyNo(10010010)},
 		{"SC123123", ScottishCompanyNo(123123)},
 		{"1", EnglishCompanyNo(1)},
+		{"sc000104", ScottishCompanyNo(104)},
+		{" 00000001 ", EnglishCompanyNo(1)},
+		{"OC301234", PrefixedCompanyNo{"OC", 301234}},
+		{"NI012345", PrefixedCompanyNo{"NI", 12345}},
+		{"SO300001", PrefixedCompanyNo{"SO", 300001}},
+		{"LP001234", PrefixedCompanyNo{"LP", 1234}},
+		{"FC012345", PrefixedCompanyNo{"FC", 12345}},
+		{"R0000123", PrefixedCompanyNo{"R", 123}},
+		{"CE000001", PrefixedCompanyNo{"CE", 1}},
+		{"ip1234", PrefixedCompanyNo{"IP", 1234}},
+		{"OE012345", PrefixedCompanyNo{"OE", 12345}},
+		{"oe000001", PrefixedCompanyNo{"OE", 1}},
 	}
 
 	for _, test := range tests {
@@ -56,4 +71,354 @@ func TestCompanyNumberFromString(t *testing.T) {
 			assert.Contains(err.Error(), "invalid syntax")
 		}
 	})
+
+	t.Run("validates input", func(t *testing.T) {
+		type test struct {
+			inp    string
+			reason string
+		}
+
+		tests := []test{
+			{"", "empty"},
+			{"123456789", "expected at most 8 digits"},
+			{"SC1234567", "expected at most 6 digits"},
+			{"R00001234", "expected at most 7 digits"},
+			{"XX123456", `unknown prefix "XX"`},
+			{"OC12-456", "invalid number"},
+			{"OE1234567", "expected at most 6 digits"},
+			{"OE000000", "number must be greater than zero"},
+			{"00000000", "number must be greater than zero"},
+		}
+
+		for _, test := range tests {
+			assert := assert.New(t)
+
+			number, err := CompanyNumberFromString(test.inp)
+
+			var numberErr *CompanyNumberError
+
+			if assert.ErrorAs(err, &numberErr, test.inp) {
+				assert.Nil(number)
+				assert.Equal(test.inp, numberErr.Input)
+				assert.Equal(test.reason, numberErr.Reason)
+				assert.ErrorIs(err, ErrInvalidCompanyNo)
+			}
+		}
+	})
+}
+
+func TestPrefixedCompanyNo(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("OC301234", PrefixedCompanyNo{"OC", 301234}.String())
+	assert.Equal("R0000123", PrefixedCompanyNo{"R", 123}.String())
+	assert.Equal("NI000100", PrefixedCompanyNo{"NI", 99}.Next().String())
+}
+
+func TestCompanyNumberError(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := CompanyNumberFromString("SC")
+
+	assert.EqualError(err, `invalid company number "SC": missing number: invalid syntax`)
+	assert.ErrorIs(err, strconv.ErrSyntax)
+}
+
+func TestDescribeCompanyNumber(t *testing.T) {
+	type test struct {
+		inp CompanyNumber
+		exp CompanyNumberInfo
+	}
+
+	tests := []test{
+		{EnglishCompanyNo(1), CompanyNumberInfo{"", JurisdictionEnglandWales, EntityKindCompany}},
+		{ScottishCompanyNo(1), CompanyNumberInfo{"SC", JurisdictionScotland, EntityKindCompany}},
+		{PrefixedCompanyNo{"NI", 1}, CompanyNumberInfo{"NI", JurisdictionNorthernIreland, EntityKindCompany}},
+		{PrefixedCompanyNo{"R", 1}, CompanyNumberInfo{"R", JurisdictionNorthernIreland, EntityKindCompany}},
+		{PrefixedCompanyNo{"OC", 1}, CompanyNumberInfo{"OC", JurisdictionEnglandWales, EntityKindLimitedLiabilityPartnership}},
+		{PrefixedCompanyNo{"SL", 1}, CompanyNumberInfo{"SL", JurisdictionScotland, EntityKindLimitedPartnership}},
+		{PrefixedCompanyNo{"NF", 1}, CompanyNumberInfo{"NF", JurisdictionNorthernIreland, EntityKindOverseasCompany}},
+		{PrefixedCompanyNo{"SP", 1}, CompanyNumberInfo{"SP", JurisdictionScotland, EntityKindRegisteredSociety}},
+		{PrefixedCompanyNo{"CE", 1}, CompanyNumberInfo{"CE", JurisdictionEnglandWales, EntityKindCharitableIncorporatedOrganisation}},
+		{PrefixedCompanyNo{"OE", 1}, CompanyNumberInfo{"OE", JurisdictionUnitedKingdom, EntityKindOverseasEntity}},
+		{PrefixedCompanyNo{"QQ", 1}, CompanyNumberInfo{"QQ", "", EntityKindUnknown}},
+		{MustParseCompanyNo("SC000104"), CompanyNumberInfo{"SC", JurisdictionScotland, EntityKindCompany}},
+		{CompanyNo{}, CompanyNumberInfo{"", "", EntityKindUnknown}},
+		{decodeCompanyNo("12AB"), CompanyNumberInfo{"", "", EntityKindUnknown}},
+		{decodeCompanyNo("SC"), CompanyNumberInfo{"SC", "", EntityKindUnknown}},
+		{EnglishCompanyNo(0), CompanyNumberInfo{"", "", EntityKindUnknown}},
+		{EnglishCompanyNo(100000000), CompanyNumberInfo{"", "", EntityKindUnknown}},
+		{PrefixedCompanyNo{"OC", 1234567}, CompanyNumberInfo{"OC", "", EntityKindUnknown}},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.exp, DescribeCompanyNumber(test.inp), test.inp.String())
+	}
+}
+
+func TestCompanyNumberPrefixesAreDescribed(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, prefix := range CompanyNumberPrefixes {
+		info := DescribeCompanyNumber(PrefixedCompanyNo{prefix, 1})
+
+		assert.Equal(prefix, info.Prefix)
+		assert.NotEmpty(info.Jurisdiction, prefix)
+		assert.NotEqual(EntityKindUnknown, info.EntityKind, prefix)
+	}
+
+	assert.Len(companyNumberInfo, len(CompanyNumberPrefixes)+1)
+}
+
+func TestCompanyNo(t *testing.T) {
+	assert := assert.New(t)
+
+	c := MustParseCompanyNo("sc000104")
+
+	assert.Equal("SC000104", c.String())
+	assert.Equal(ScottishCompanyNo(104), c.Number())
+	assert.Equal("SC000105", c.Next().String())
+	assert.False(c.IsZero())
+	assert.Equal(c, NewCompanyNo(ScottishCompanyNo(104)))
+	assert.Equal(c, NewCompanyNo(c))
+
+	var zero CompanyNo
+
+	assert.True(zero.IsZero())
+	assert.False(zero.Valid())
+	assert.NoError(zero.Err())
+	assert.Equal("", zero.String())
+	assert.Equal(zero, zero.Next())
+
+	_, err := ParseCompanyNo("XX000001")
+	assert.ErrorIs(err, ErrInvalidCompanyNo)
+
+	assert.Panics(func() {
+		MustParseCompanyNo("")
+	})
+}
+
+func TestCompanyNoJSON(t *testing.T) {
+	assert := assert.New(t)
+
+	var v struct {
+		A CompanyNo  `json:"a"`
+		B CompanyNo  `json:"b"`
+		C CompanyNo  `json:"c"`
+		D *CompanyNo `json:"d"`
+	}
+
+	err := json.Unmarshal([]byte(`{"a": "oc301234", "b": null, "c": "", "d": "00000001"}`), &v)
+
+	assert.NoError(err)
+	assert.Equal(MustParseCompanyNo("OC301234"), v.A)
+	assert.True(v.B.IsZero())
+	assert.True(v.C.IsZero())
+	assert.Equal(EnglishCompanyNo(1), v.D.Number())
+
+	b, err := json.Marshal(v)
+
+	assert.NoError(err)
+	assert.JSONEq(`{"a": "OC301234", "b": null, "c": null, "d": "00000001"}`, string(b))
+
+	// unrecognised numbers are kept as is rather than failing to decode
+	err = json.Unmarshal([]byte(`{"a": "ZZ123456"}`), &v)
+
+	assert.NoError(err)
+	assert.False(v.A.IsZero())
+	assert.False(v.A.Valid())
+	assert.ErrorIs(v.A.Err(), ErrInvalidCompanyNo)
+	assert.Nil(v.A.Number())
+	assert.Equal("ZZ123456", v.A.String())
+	assert.Equal(v.A, v.A.Next())
+
+	b, err = json.Marshal(v.A)
+
+	assert.NoError(err)
+	assert.Equal(`"ZZ123456"`, string(b))
+
+	err = json.Unmarshal([]byte(`{"a": 1}`), &v)
+	assert.Error(err)
+}
+
+func TestCompanyNoDecodesResources(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		profile     CompanyProfile
+		register    CompanyRegister
+		metadata    DocumentMetadata
+		appointment AppointmentList
+		dissolved   DissolvedCompanySearchItem
+	)
+
+	assert.NoError(json.Unmarshal([]byte(`{"branch_company_details": {"parent_company_number": "FC012345"}}`), &profile))
+	assert.NoError(json.Unmarshal([]byte(`{"company_number": "SC000104"}`), &register))
+	assert.NoError(json.Unmarshal([]byte(`{"company_number": "ZZ000001"}`), &metadata))
+	assert.NoError(json.Unmarshal([]byte(`{"items": [{"appointed_to": {"company_number": "OC301234"}}]}`), &appointment))
+	assert.NoError(json.Unmarshal([]byte(`{"previous_company_names": [{"company_number": "00000001"}]}`), &dissolved))
+
+	assert.Equal(PrefixedCompanyNo{"FC", 12345}, profile.BranchCompanyDetails.ParentCompanyNumber.Number())
+	assert.Equal(ScottishCompanyNo(104), register.CompanyNumber.Number())
+	assert.False(metadata.CompanyNumber.Valid())
+	assert.Equal("ZZ000001", metadata.CompanyNumber.String())
+	assert.Equal(PrefixedCompanyNo{"OC", 301234}, appointment.Items[0].AppointedTo.CompanyNumber.Number())
+	assert.Equal(EnglishCompanyNo(1), dissolved.PreviousCompanyNames[0].CompanyNumber.Number())
+}
+
+func TestCompanyNoDecodesSearchWithUnknownPrefix(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"items": [{"company_number": "ZZ000001"}, {"company_number": "00000001"}]}`))
+	})
+
+	defer ts.Close()
+
+	results, err := c.Search().Companies(SearchParams{Query: "test"})
+
+	if assert.NoError(err) && assert.Len(results.Items, 2) {
+		unknown := results.Items[0].CompanyNumber
+
+		assert.False(unknown.Valid())
+		assert.Equal("ZZ000001", unknown.String())
+		assert.ErrorIs(unknown.Err(), ErrInvalidCompanyNo)
+
+		assert.True(results.Items[1].CompanyNumber.Valid())
+		assert.NoError(results.Items[1].CompanyNumber.Err())
+		assert.Equal(EnglishCompanyNo(1), results.Items[1].CompanyNumber.Number())
+	}
+}
+
+func TestCompanyNoText(t *testing.T) {
+	assert := assert.New(t)
+
+	var c CompanyNo
+
+	assert.NoError(c.UnmarshalText([]byte("NI012345")))
+	assert.Equal(PrefixedCompanyNo{"NI", 12345}, c.Number())
+
+	b, err := c.MarshalText()
+
+	assert.NoError(err)
+	assert.Equal([]byte("NI012345"), b)
+}
+
+func TestCompanyNoSQL(t *testing.T) {
+	assert := assert.New(t)
+
+	var c CompanyNo
+
+	assert.NoError(c.Scan("SC000104"))
+	assert.Equal(ScottishCompanyNo(104), c.Number())
+
+	v, err := c.Value()
+
+	assert.NoError(err)
+	assert.Equal("SC000104", v)
+
+	assert.NoError(c.Scan([]byte("00000001")))
+	assert.Equal(EnglishCompanyNo(1), c.Number())
+
+	assert.NoError(c.Scan("ZZ000001"))
+	assert.False(c.Valid())
+
+	v, err = c.Value()
+
+	assert.NoError(err)
+	assert.Equal("ZZ000001", v)
+
+	assert.NoError(c.Scan(nil))
+	assert.True(c.IsZero())
+
+	v, err = c.Value()
+
+	assert.NoError(err)
+	assert.Nil(v)
+
+	assert.ErrorIs(c.Scan(1), ErrInvalidCompanyNo)
+}
+
+func TestCompanyNoDecodesProfiles(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte(`{"company_number": "SC000104"}`))
+	})
+
+	defer ts.Close()
+
+	profile, err := c.Company(ScottishCompanyNo(104)).Profile()
+
+	assert.NoError(err)
+	assert.Equal(ScottishCompanyNo(104), profile.CompanyNumber.Number())
+	assert.Same(c, c.Company(profile.CompanyNumber).Client)
+}
+
+func TestCompanyNumberRange(t *testing.T) {
+	assert := assert.New(t)
+
+	r, err := NewCompanyNumberRange(ScottishCompanyNo(8), PrefixedCompanyNo{Prefix: "SC", Number: 12})
+	assert.NoError(err)
+	assert.Equal(5, r.Len())
+
+	assert.True(r.Contains(ScottishCompanyNo(8)))
+	assert.True(r.Contains(ScottishCompanyNo(12)))
+	assert.False(r.Contains(ScottishCompanyNo(13)))
+	assert.False(r.Contains(EnglishCompanyNo(10)))
+
+	var numbers []string
+
+	r.Each(func(n CompanyNumber) bool {
+		numbers = append(numbers, n.String())
+		return true
+	})
+
+	assert.Equal([]string{"SC000008", "SC000009", "SC000010", "SC000011", "SC000012"}, numbers)
+
+	assert.Equal(3, r.After(ScottishCompanyNo(9)).Len())
+	assert.Equal(5, r.After(ScottishCompanyNo(1)).Len())
+	assert.Equal(0, r.After(ScottishCompanyNo(12)).Len())
+	assert.Equal(0, r.After(ScottishCompanyNo(20)).Len())
+}
+
+func TestCompanyNumberNextStopsAtMaximum(t *testing.T) {
+	tests := []struct {
+		inp CompanyNumber
+		exp string
+	}{
+		{EnglishCompanyNo(99999998), "99999999"},
+		{EnglishCompanyNo(99999999), "99999999"},
+		{ScottishCompanyNo(999999), "SC999999"},
+		{PrefixedCompanyNo{"R", 9999999}, "R9999999"},
+		{PrefixedCompanyNo{"OC", 999999}, "OC999999"},
+		{MustParseCompanyNo("NI999999"), "NI999999"},
+	}
+
+	for _, test := range tests {
+		next := test.inp.Next()
+
+		assert.Equal(t, test.exp, next.String())
+
+		_, err := CompanyNumberFromString(next.String())
+		assert.NoError(t, err, next.String())
+	}
+}
+
+func TestCompanyNumberRangeErrors(t *testing.T) {
+	tests := []struct {
+		from CompanyNumber
+		to   CompanyNumber
+	}{
+		{EnglishCompanyNo(1), ScottishCompanyNo(2)},
+		{EnglishCompanyNo(2), EnglishCompanyNo(1)},
+		{nil, EnglishCompanyNo(1)},
+		{CompanyNo{}, EnglishCompanyNo(1)},
+		{EnglishCompanyNo(1), EnglishCompanyNo(100000000)},
+	}
+
+	for _, test := range tests {
+		_, err := NewCompanyNumberRange(test.from, test.to)
+		assert.ErrorIs(t, err, ErrInvalidRange)
+	}
 }
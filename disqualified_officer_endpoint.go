@@ -0,0 +1,59 @@
+package comphouse
+
+import (
+	"context"
+	"strings"
+)
+
+// DisqualifiedOfficerEndpoint is a struct that can be used to fetch the
+// disqualification records of an officer
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/officer-disqualifications
+type DisqualifiedOfficerEndpoint struct {
+	Client *Client
+	ID     string
+}
+
+// helper method to format a path for a disqualified officer
+func (m *DisqualifiedOfficerEndpoint) path(extra ...string) string {
+	p := "/disqualified-officers"
+
+	if len(extra) > 0 {
+		p += "/" + strings.Join(extra, "/")
+	}
+
+	return p + "/" + m.ID
+}
+
+// Get a natural officer's disqualifications
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/officer-disqualifications/get-natural-officer
+func (m *DisqualifiedOfficerEndpoint) Natural() (*NaturalDisqualifiedOfficer, error) {
+	return m.NaturalContext(context.Background())
+}
+
+// NaturalContext is the same as Natural but uses the provided context
+func (m *DisqualifiedOfficerEndpoint) NaturalContext(ctx context.Context) (*NaturalDisqualifiedOfficer, error) {
+	d := &NaturalDisqualifiedOfficer{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("natural"), d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Get a corporate officer's disqualifications
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/officer-disqualifications/get-corporate-officer
+func (m *DisqualifiedOfficerEndpoint) Corporate() (*CorporateDisqualifiedOfficer, error) {
+	return m.CorporateContext(context.Background())
+}
+
+// CorporateContext is the same as Corporate but uses the provided context
+func (m *DisqualifiedOfficerEndpoint) CorporateContext(ctx context.Context) (*CorporateDisqualifiedOfficer, error) {
+	d := &CorporateDisqualifiedOfficer{}
+
+	if err := m.Client.GetJSONContext(ctx, m.path("corporate"), d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
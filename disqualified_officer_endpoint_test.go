@@ -0,0 +1,147 @@
+package comphouse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisqualifiedOfficerEndpointNatural(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"forename": "John",
+			"surname": "Doe",
+			"disqualifications": [{
+				"case_identifier": "INV1234",
+				"disqualification_type": "court-order",
+				"disqualified_from": "2020-01-01",
+				"disqualified_until": "2025-01-01",
+				"heard_on": "2019-12-01",
+				"reason": {"act": "company-directors-disqualification-act-1986", "section": "6"}
+			}],
+			"permissions_to_act": [{"company_names": ["Company Name"], "granted_on": "2020-06-01"}]
+		}`)
+	})
+
+	defer ts.Close()
+
+	d, err := c.DisqualifiedOfficer("abc123").Natural()
+
+	if assert.NoError(err) {
+		assert.Equal("/disqualified-officers/natural/abc123", uri)
+		assert.Equal("Doe", d.Surname)
+		assert.Equal("2019-12-01", d.Disqualifications[0].HeardOn)
+		assert.Equal("6", d.Disqualifications[0].Reason.Section)
+		assert.Equal([]string{"Company Name"}, d.PermissionsToAct[0].CompanyNames)
+	}
+}
+
+func TestDisqualifiedOfficerEndpointCorporate(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"name": "Corporate Officer Ltd",
+			"company_number": "00000001",
+			"disqualifications": [{"disqualification_type": "undertaking", "undertaken_on": "2020-01-01"}]
+		}`)
+	})
+
+	defer ts.Close()
+
+	d, err := c.DisqualifiedOfficer("abc123").Corporate()
+
+	if assert.NoError(err) {
+		assert.Equal("/disqualified-officers/corporate/abc123", uri)
+		assert.Equal("Corporate Officer Ltd", d.Name)
+		assert.Equal("2020-01-01", d.Disqualifications[0].UndertakenOn)
+	}
+}
+
+func TestDisqualifiedOfficerEndpointHandlesErrors(t *testing.T) {
+	type test struct {
+		name string
+		f    func(*DisqualifiedOfficerEndpoint) error
+	}
+
+	tests := []test{
+		{
+			"DisqualifiedOfficerEndpoint.Natural",
+			func(d *DisqualifiedOfficerEndpoint) error {
+				_, err := d.Natural()
+				return err
+			},
+		},
+		{
+			"DisqualifiedOfficerEndpoint.Corporate",
+			func(d *DisqualifiedOfficerEndpoint) error {
+				_, err := d.Corporate()
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(404)
+			})
+
+			defer ts.Close()
+
+			assert.ErrorIs(test.f(c.DisqualifiedOfficer("abc123")), ErrNotFound)
+		})
+	}
+}
+
+func TestDisqualifiedOfficerEndpointRespectsContext(t *testing.T) {
+	type test struct {
+		name string
+		f    func(context.Context, *DisqualifiedOfficerEndpoint) error
+	}
+
+	tests := []test{
+		{
+			"DisqualifiedOfficerEndpoint.NaturalContext",
+			func(ctx context.Context, d *DisqualifiedOfficerEndpoint) error {
+				_, err := d.NaturalContext(ctx)
+				return err
+			},
+		},
+		{
+			"DisqualifiedOfficerEndpoint.CorporateContext",
+			func(ctx context.Context, d *DisqualifiedOfficerEndpoint) error {
+				_, err := d.CorporateContext(ctx)
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ts, c := createTestServerWithResponse(map[string]string{"name": "Name"})
+
+			defer ts.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			assert.Same(context.Canceled, test.f(ctx, c.DisqualifiedOfficer("abc123")))
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package comphouse
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Content types that filing documents may be available in
+const (
+	DocumentPDF   = "application/pdf"
+	DocumentXHTML = "application/xhtml+xml"
+	DocumentJSON  = "application/json"
+	DocumentXML   = "application/xml"
+	DocumentCSV   = "text/csv"
+)
+
+// DocumentEndpoint is a struct that can be used to query the Companies House
+// Document API, which is served from the Client's DocumentHost
+// https://developer-specs.company-information.service.gov.uk/document-api/reference
+type DocumentEndpoint struct {
+	Client *Client
+	ID     string
+}
+
+// DocumentIDFromLink extracts a document's ID from a link to its metadata,
+// such as the Links.DocumentMetadata field of a FilingHistoryItem
+func DocumentIDFromLink(link string) (string, error) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return "", ErrInvalidDocumentLink
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+
+	if len(parts) < 2 || parts[0] != "document" || parts[1] == "" {
+		return "", ErrInvalidDocumentLink
+	}
+
+	return parts[1], nil
+}
+
+// helper method to format a URL for a document
+func (m *DocumentEndpoint) url(extra ...string) string {
+	u := m.Client.Protocol + "://" + m.Client.DocumentHost + "/document/" + m.ID
+
+	if len(extra) > 0 {
+		u += "/" + strings.Join(extra, "/")
+	}
+
+	return u
+}
+
+// Get the metadata of a document, including the content types it is available in
+// https://developer-specs.company-information.service.gov.uk/document-api/reference/document-metadata/fetch-a-document-s-metadata
+func (m *DocumentEndpoint) Metadata() (*DocumentMetadata, error) {
+	return m.MetadataContext(context.Background())
+}
+
+// MetadataContext is the same as Metadata but uses the provided context
+func (m *DocumentEndpoint) MetadataContext(ctx context.Context) (*DocumentMetadata, error) {
+	d := &DocumentMetadata{}
+
+	if err := m.Client.GetJSONContext(ctx, m.url(), d); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Content downloads the document in the requested content type and streams it
+// to w, returning the number of bytes written. The Document API responds with
+// a redirect to the content's location which is followed automatically. If
+// contentType is empty, the PDF version of the document is requested
+// https://developer-specs.company-information.service.gov.uk/document-api/reference/document-content/fetch-a-document
+func (m *DocumentEndpoint) Content(contentType string, w io.Writer) (int64, error) {
+	return m.ContentContext(context.Background(), contentType, w)
+}
+
+// ContentContext is the same as Content but uses the provided context
+func (m *DocumentEndpoint) ContentContext(ctx context.Context, contentType string, w io.Writer) (int64, error) {
+	if contentType == "" {
+		contentType = DocumentPDF
+	}
+
+	req, err := m.Client.NewRequestContext(ctx, http.MethodGet, m.url("content"), nil)
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Accept", contentType)
+
+	resp, err := m.Client.DoRequest(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, contextError(ctx, err)
+	}
+
+	return n, nil
+}
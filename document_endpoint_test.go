@@ -0,0 +1,162 @@
+package comphouse
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestDocumentServer(f http.HandlerFunc) (*DocumentEndpoint, func()) {
+	ts, c := createTestServer(f)
+	c.DocumentHost = c.Host
+
+	return c.Document("abc123"), ts.Close
+}
+
+func TestDocumentIDFromLink(t *testing.T) {
+	type test struct {
+		inp string
+		exp string
+		err error
+	}
+
+	tests := []test{
+		{"https://document-api.company-information.service.gov.uk/document/abc123", "abc123", nil},
+		{"https://document-api.company-information.service.gov.uk/document/abc123/content", "abc123", nil},
+		{"/document/abc123", "abc123", nil},
+		{"https://document-api.company-information.service.gov.uk/", "", ErrInvalidDocumentLink},
+		{"/officers/abc123/appointments", "", ErrInvalidDocumentLink},
+		{"%", "", ErrInvalidDocumentLink},
+	}
+
+	for _, test := range tests {
+		t.Run(test.inp, func(t *testing.T) {
+			assert := assert.New(t)
+
+			id, err := DocumentIDFromLink(test.inp)
+
+			assert.Equal(test.exp, id)
+			assert.Equal(test.err, err)
+		})
+	}
+}
+
+func TestDocumentEndpointMetadata(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	d, close := createTestDocumentServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"company_number": "00000001",
+			"pages": 3,
+			"significant_date": "2020-12-31",
+			"resources": {
+				"application/xhtml+xml": {"content_length": 2048},
+				"application/pdf": {"content_length": 1024}
+			}
+		}`)
+	})
+
+	defer close()
+
+	m, err := d.Metadata()
+
+	if assert.NoError(err) {
+		assert.Equal("/document/abc123", uri)
+		assert.Equal(3, m.Pages)
+		assert.Equal("2020-12-31", m.SignificantDate)
+		assert.Equal([]string{DocumentPDF, DocumentXHTML}, m.ContentTypes())
+		assert.Equal(int64(1024), m.Resources[DocumentPDF].ContentLength)
+	}
+}
+
+func TestDocumentEndpointContent(t *testing.T) {
+	type test struct {
+		contentType string
+		exp         string
+	}
+
+	tests := []test{
+		{"", DocumentPDF},
+		{DocumentPDF, DocumentPDF},
+		{DocumentXHTML, DocumentXHTML},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+
+			d, close := createTestDocumentServer(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/document/abc123/content":
+					http.Redirect(w, r, "/files/abc123", http.StatusFound)
+				case "/files/abc123":
+					w.Header().Set("Content-Type", r.Header.Get("Accept"))
+					fmt.Fprint(w, strings.Repeat(r.Header.Get("Accept"), 1000))
+				default:
+					w.WriteHeader(404)
+				}
+			})
+
+			defer close()
+
+			var buff bytes.Buffer
+
+			n, err := d.Content(test.contentType, &buff)
+
+			if assert.NoError(err) {
+				assert.Equal(int64(len(test.exp)*1000), n)
+				assert.Equal(strings.Repeat(test.exp, 1000), buff.String())
+			}
+		})
+	}
+}
+
+func TestDocumentEndpointHandlesErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	d, close := createTestDocumentServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(404)
+	})
+
+	defer close()
+
+	m, err := d.Metadata()
+
+	assert.Nil(m)
+	assert.ErrorIs(err, ErrNotFound)
+
+	var buff bytes.Buffer
+
+	n, err := d.Content(DocumentPDF, &buff)
+
+	assert.Zero(n)
+	assert.ErrorIs(err, ErrNotFound)
+	assert.Zero(buff.Len())
+}
+
+func TestDocumentEndpointRespectsContext(t *testing.T) {
+	assert := assert.New(t)
+
+	d, close := createTestDocumentServer(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, "{}")
+	})
+
+	defer close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := d.MetadataContext(ctx)
+	assert.Same(context.Canceled, err)
+
+	_, err = d.ContentContext(ctx, DocumentPDF, &bytes.Buffer{})
+	assert.Same(context.Canceled, err)
+}
@@ -11,10 +11,7 @@ import (
 )
 
 func client(t *testing.T) *comphouse.Client {
-	c := comphouse.NewClient(
-		"api.company-information.service.gov.uk",
-		comphouse.APIKey(os.Getenv("CH_API_KEY")),
-	)
+	c := comphouse.New(comphouse.APIKey(os.Getenv("CH_API_KEY")))
 
 	c.Hooks.AfterRequest = append(c.Hooks.AfterRequest, func(resp *http.Response) {
 		t.Logf("%s %s %s", resp.Request.Method, resp.Request.URL, resp.Status)
@@ -43,7 +40,7 @@ func TestCompanyProfile(t *testing.T) {
 			profile, err := client(t).Company(test.number).Profile()
 
 			if test.err != nil {
-				assert.Same(test.err, err)
+				assert.ErrorIs(err, test.err)
 			} else if assert.NoError(err) {
 				assert.Equal(test.name, profile.CompanyName)
 			}
@@ -104,6 +101,18 @@ func TestAllResourcesCanBeDecoded(t *testing.T) {
 				return c.Company(companyNumber).Charge("n-LzQBYIroD60vcrZtWHICCkqhk")
 			},
 		},
+		{
+			"CompanyEndpoint.FilingHistory",
+			func(c *comphouse.Client) (interface{}, error) {
+				return c.Company(companyNumber).FilingHistory(comphouse.FilingHistoryParams{})
+			},
+		},
+		{
+			"CompanyEndpoint.PSCs",
+			func(c *comphouse.Client) (interface{}, error) {
+				return c.Company(companyNumber).PSCs(comphouse.PSCParams{})
+			},
+		},
 		{
 			"SearchEndpoint.All",
 			func(c *comphouse.Client) (interface{}, error) {
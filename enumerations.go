@@ -0,0 +1,85 @@
+package comphouse
+
+// CompanyStatus is the status of a company
+// https://github.com/companieshouse/api-enumerations/blob/master/constants.yml
+type CompanyStatus string
+
+// Company statuses recognised by the Companies House API
+const (
+	CompanyStatusActive                CompanyStatus = "active"
+	CompanyStatusAdministration        CompanyStatus = "administration"
+	CompanyStatusClosed                CompanyStatus = "closed"
+	CompanyStatusConvertedClosed       CompanyStatus = "converted-closed"
+	CompanyStatusDissolved             CompanyStatus = "dissolved"
+	CompanyStatusInsolvencyProceedings CompanyStatus = "insolvency-proceedings"
+	CompanyStatusLiquidation           CompanyStatus = "liquidation"
+	CompanyStatusOpen                  CompanyStatus = "open"
+	CompanyStatusReceivership          CompanyStatus = "receivership"
+	CompanyStatusRegistered            CompanyStatus = "registered"
+	CompanyStatusRemoved               CompanyStatus = "removed"
+	CompanyStatusVoluntaryArrangement  CompanyStatus = "voluntary-arrangement"
+)
+
+// CompanyType is the legal type of a company
+// https://github.com/companieshouse/api-enumerations/blob/master/constants.yml
+type CompanyType string
+
+// Company types recognised by the Companies House API
+const (
+	CompanyTypeAssuranceCompany                       CompanyType = "assurance-company"
+	CompanyTypeCharitableIncorporatedOrganisation     CompanyType = "charitable-incorporated-organisation"
+	CompanyTypeConvertedOrClosed                      CompanyType = "converted-or-closed"
+	CompanyTypeEEIG                                   CompanyType = "eeig"
+	CompanyTypeEuropeanPublicLimitedLiabilityCompany  CompanyType = "european-public-limited-liability-company-se"
+	CompanyTypeFurtherEducationOrSixthFormCollege     CompanyType = "further-education-or-sixth-form-college-corporation"
+	CompanyTypeIndustrialAndProvidentSociety          CompanyType = "industrial-and-provident-society"
+	CompanyTypeInvestmentCompanyWithVariableCapital   CompanyType = "investment-company-with-variable-capital"
+	CompanyTypeLimitedPartnership                     CompanyType = "limited-partnership"
+	CompanyTypeLLP                                    CompanyType = "llp"
+	CompanyTypeLtd                                    CompanyType = "ltd"
+	CompanyTypeNorthernIreland                        CompanyType = "northern-ireland"
+	CompanyTypeNorthernIrelandOther                   CompanyType = "northern-ireland-other"
+	CompanyTypeOldPublicCompany                       CompanyType = "old-public-company"
+	CompanyTypeOther                                  CompanyType = "other"
+	CompanyTypeOverseaCompany                         CompanyType = "oversea-company"
+	CompanyTypePLC                                    CompanyType = "plc"
+	CompanyTypePrivateLimitedGuaranteeNSC             CompanyType = "private-limited-guarant-nsc"
+	CompanyTypePrivateLimitedGuaranteeNSCExemption    CompanyType = "private-limited-guarant-nsc-limited-exemption"
+	CompanyTypePrivateLimitedSharesSection30Exemption CompanyType = "private-limited-shares-section-30-exemption"
+	CompanyTypePrivateUnlimited                       CompanyType = "private-unlimited"
+	CompanyTypePrivateUnlimitedNSC                    CompanyType = "private-unlimited-nsc"
+	CompanyTypeProtectedCellCompany                   CompanyType = "protected-cell-company"
+	CompanyTypeRegisteredOverseasEntity               CompanyType = "registered-overseas-entity"
+	CompanyTypeRegisteredSocietyNonJurisdictional     CompanyType = "registered-society-non-jurisdictional"
+	CompanyTypeRoyalCharter                           CompanyType = "royal-charter"
+	CompanyTypeScottishCharitableIncorporatedOrg      CompanyType = "scottish-charitable-incorporated-organisation"
+	CompanyTypeScottishPartnership                    CompanyType = "scottish-partnership"
+	CompanyTypeUKEstablishment                        CompanyType = "uk-establishment"
+	CompanyTypeUnregisteredCompany                    CompanyType = "unregistered-company"
+)
+
+// CompanySubtype further classifies a company's type
+// https://github.com/companieshouse/api-enumerations/blob/master/constants.yml
+type CompanySubtype string
+
+// Company subtypes recognised by the Companies House API
+const (
+	CompanySubtypeCommunityInterestCompany      CompanySubtype = "community-interest-company"
+	CompanySubtypePrivateFundLimitedPartnership CompanySubtype = "private-fund-limited-partnership"
+)
+
+// Jurisdiction is the jurisdiction a company is registered in
+// https://github.com/companieshouse/api-enumerations/blob/master/constants.yml
+type Jurisdiction string
+
+// Jurisdictions recognised by the Companies House API
+const (
+	JurisdictionEnglandWales    Jurisdiction = "england-wales"
+	JurisdictionWales           Jurisdiction = "wales"
+	JurisdictionEngland         Jurisdiction = "england"
+	JurisdictionScotland        Jurisdiction = "scotland"
+	JurisdictionNorthernIreland Jurisdiction = "northern-ireland"
+	JurisdictionEuropeanUnion   Jurisdiction = "european-union"
+	JurisdictionUnitedKingdom   Jurisdiction = "united-kingdom"
+	JurisdictionNonEU           Jurisdiction = "noneu"
+)
@@ -0,0 +1,138 @@
+package comphouse
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Hosts of the Companies House APIs
+const (
+	DefaultHost  = "api.company-information.service.gov.uk"
+	SandboxHost  = "api-sandbox.company-information.service.gov.uk"
+	TestDataHost = "test-data-sandbox.company-information.service.gov.uk"
+)
+
+// ErrUnknownEnvironment is returned when looking up an environment that
+// doesn't exist
+var ErrUnknownEnvironment = errors.New("unknown environment")
+
+// Environment holds the hosts used to reach one deployment of the Companies
+// House APIs
+type Environment struct {
+	Name         string
+	Protocol     string
+	Host         string
+	DocumentHost string
+	StreamHost   string
+
+	// TestDataHost is the host of the test data generator used to create
+	// companies in the sandbox. It is empty for environments without one
+	TestDataHost string
+}
+
+// Environment presets
+var (
+	// Live is the production Companies House Public Data API
+	Live = Environment{
+		Name:         "live",
+		Protocol:     DefaultProtocol,
+		Host:         DefaultHost,
+		DocumentHost: DefaultDocumentHost,
+		StreamHost:   DefaultStreamHost,
+	}
+
+	// Sandbox is the Companies House sandbox, which serves test companies
+	// created using the test data generator
+	Sandbox = Environment{
+		Name:         "sandbox",
+		Protocol:     DefaultProtocol,
+		Host:         SandboxHost,
+		DocumentHost: DefaultDocumentHost,
+		StreamHost:   DefaultStreamHost,
+		TestDataHost: TestDataHost,
+	}
+)
+
+// EnvironmentByName returns the preset with the specified name, ignoring
+// case, so the environment can be chosen using configuration
+func EnvironmentByName(name string) (Environment, error) {
+	for _, env := range []Environment{Live, Sandbox} {
+		if strings.EqualFold(env.Name, name) {
+			return env, nil
+		}
+	}
+
+	return Environment{}, fmt.Errorf("%w: %q", ErrUnknownEnvironment, name)
+}
+
+// Option configures a Client created using New
+type Option func(*options)
+
+// options holds the Client being configured by New along with settings that
+// are only applied once every Option has run, so options can be passed in
+// any order
+type options struct {
+	client  *Client
+	timeout *time.Duration
+}
+
+// WithEnvironment sets the protocol and hosts used by the Client
+func WithEnvironment(env Environment) Option {
+	return func(o *options) {
+		o.client.Protocol = env.Protocol
+		o.client.Host = env.Host
+		o.client.DocumentHost = env.DocumentHost
+		o.client.StreamHost = env.StreamHost
+	}
+}
+
+// WithHTTPClient sets the http.Client used to send requests. A nil
+// http.Client uses the default
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *options) {
+		if hc == nil {
+			hc = &http.Client{Timeout: DefaultTimeout}
+		}
+
+		o.client.HTTP = hc
+	}
+}
+
+// WithTimeout sets the timeout of the Client's http.Client. It's applied after
+// every other option, and the http.Client is copied first so one passed to
+// WithHTTPClient isn't modified
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = &timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.client.UserAgent = userAgent
+	}
+}
+
+// New creates a new Client for the Live environment authenticated using the
+// provided Authenticator and configured using the provided options
+func New(auth Authenticator, opts ...Option) *Client {
+	c := NewClient(DefaultHost, auth)
+
+	o := &options{client: c}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.timeout != nil {
+		hc := *c.HTTP
+		hc.Timeout = *o.timeout
+		c.HTTP = &hc
+	}
+
+	return c
+}
@@ -0,0 +1,111 @@
+package comphouse
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	assert := assert.New(t)
+
+	c := New(APIKey("key"))
+
+	assert.Equal(APIKey("key"), c.Auth)
+	assert.Equal(DefaultProtocol, c.Protocol)
+	assert.Equal(DefaultHost, c.Host)
+	assert.Equal(DefaultDocumentHost, c.DocumentHost)
+	assert.Equal(DefaultStreamHost, c.StreamHost)
+	assert.Equal(DefaultTimeout, c.HTTP.Timeout)
+	assert.NotNil(c.Limiter)
+}
+
+func TestNewWithOptions(t *testing.T) {
+	assert := assert.New(t)
+
+	hc := &http.Client{Timeout: time.Second}
+
+	c := New(
+		nil,
+		WithEnvironment(Sandbox),
+		WithHTTPClient(hc),
+		WithTimeout(time.Minute),
+		WithUserAgent("dashboard/1.0"),
+	)
+
+	assert.Equal(SandboxHost, c.Host)
+	assert.Equal("https://api-sandbox.company-information.service.gov.uk/company/00000001", c.URL("/company/00000001"))
+	assert.Equal(time.Minute, c.HTTP.Timeout)
+	assert.Equal(time.Second, hc.Timeout)
+	assert.Equal("dashboard/1.0", c.UserAgent)
+}
+
+func TestNewOptionOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	hc := &http.Client{Timeout: time.Second}
+
+	c := New(nil, WithTimeout(time.Minute), WithHTTPClient(hc))
+
+	assert.Equal(time.Minute, c.HTTP.Timeout)
+	assert.Equal(time.Second, hc.Timeout)
+
+	c = New(nil, WithHTTPClient(nil), WithTimeout(time.Minute))
+
+	assert.NotNil(c.HTTP)
+	assert.Equal(time.Minute, c.HTTP.Timeout)
+
+	c = New(nil, WithHTTPClient(nil))
+
+	assert.Equal(DefaultTimeout, c.HTTP.Timeout)
+}
+
+func TestEnvironmentByName(t *testing.T) {
+	assert := assert.New(t)
+
+	env, err := EnvironmentByName("Sandbox")
+
+	assert.NoError(err)
+	assert.Equal(Sandbox, env)
+
+	env, err = EnvironmentByName("live")
+
+	assert.NoError(err)
+	assert.Equal(Live, env)
+
+	_, err = EnvironmentByName("staging")
+
+	assert.ErrorIs(err, ErrUnknownEnvironment)
+}
+
+func TestWithEnvironment(t *testing.T) {
+	assert := assert.New(t)
+
+	var userAgent string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		w.Write([]byte(`{}`))
+	})
+
+	defer ts.Close()
+
+	env := Environment{
+		Name:         "local",
+		Protocol:     "http",
+		Host:         c.Host,
+		DocumentHost: c.Host,
+		StreamHost:   c.Host,
+	}
+
+	c = New(nil, WithEnvironment(env), WithUserAgent("dashboard/1.0"))
+
+	_, err := c.Company(EnglishCompanyNo(1)).Profile()
+
+	assert.NoError(err)
+	assert.Equal("dashboard/1.0", userAgent)
+	assert.Equal("http://"+c.Host+"/document/abc", c.Document("abc").url())
+	assert.Equal("http://"+c.Host+"/companies", c.Stream(StreamCompanies).url())
+}
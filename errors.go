@@ -1,8 +1,13 @@
 package comphouse
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
 var (
@@ -10,8 +15,97 @@ var (
 	ErrNotFound         = errors.New("not found")
 	ErrTooManyRequests  = errors.New("too many requests")
 	ErrUnexpectedStatus = errors.New("unexpected status")
+
+	ErrInvalidOfficerLink  = errors.New("invalid officer link")
+	ErrInvalidDocumentLink = errors.New("invalid document link")
+	ErrInvalidSearchParams = errors.New("invalid search params")
+	ErrInvalidCompanyNo    = errors.New("invalid company number")
+	ErrInvalidRange        = errors.New("invalid company number range")
+	ErrInvalidPostcode     = errors.New("invalid postcode")
 )
 
+// maximum number of bytes read from an unsuccessful response's body
+const maxErrorBodySize = 1 << 20
+
+// APIError is returned when the Companies House API responds with an
+// unsuccessful status code. It wraps one of the sentinel errors above so
+// errors.Is can still be used to check for specific failures
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Header     http.Header
+	RateLimit  RateLimit
+	Errors     []APIErrorDetail
+	err        error
+}
+
+// APIErrorDetail is a single entry in the "errors" array returned by the
+// Companies House API
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/error
+type APIErrorDetail struct {
+	Error        string            `json:"error"`
+	ErrorValues  map[string]string `json:"error_values"`
+	Location     string            `json:"location"`
+	LocationType string            `json:"location_type"`
+	Type         string            `json:"type"`
+}
+
+// newAPIError creates an APIError from an unsuccessful response. The
+// response's body is read and closed so the connection can be reused
+func newAPIError(resp *http.Response, err error) *APIError {
+	defer resp.Body.Close()
+
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		RateLimit:  RateLimitFromHeader(resp.Header),
+		err:        err,
+	}
+
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.URL = resp.Request.URL.String()
+	}
+
+	var body struct {
+		Errors []APIErrorDetail `json:"errors"`
+	}
+
+	if json.NewDecoder(io.LimitReader(resp.Body, maxErrorBodySize)).Decode(&body) == nil {
+		e.Errors = body.Errors
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxErrorBodySize))
+
+	return e
+}
+
+// Error satisfies the error interface
+func (m *APIError) Error() string {
+	msg := fmt.Sprintf("%d %s", m.StatusCode, m.err)
+
+	if m.URL != "" {
+		msg = fmt.Sprintf("%s %s: %s", m.Method, m.URL, msg)
+	}
+
+	if len(m.Errors) > 0 {
+		details := make([]string, len(m.Errors))
+		for i, e := range m.Errors {
+			details[i] = e.Error
+		}
+
+		msg += ": " + strings.Join(details, ", ")
+	}
+
+	return msg
+}
+
+// Unwrap returns the sentinel error corresponding to the response's status
+func (m *APIError) Unwrap() error {
+	return m.err
+}
+
 func statusCodeToError(status int) error {
 	errors := map[int]error{
 		http.StatusUnauthorized:    ErrUnauthorized,
@@ -29,3 +123,14 @@ func statusCodeToError(status int) error {
 
 	return nil
 }
+
+// contextError returns the context's error if it has been cancelled or its
+// deadline has been exceeded so that callers can distinguish cancellation
+// from other failures using errors.Is. Otherwise err is returned unchanged
+func contextError(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	return err
+}
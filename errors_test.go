@@ -1,7 +1,13 @@
 package comphouse
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,3 +38,92 @@ func TestStatusCodeToError(t *testing.T) {
 		})
 	}
 }
+
+type testBody struct {
+	io.Reader
+	closed bool
+}
+
+func (m *testBody) Close() error {
+	m.closed = true
+	return nil
+}
+
+func TestNewAPIError(t *testing.T) {
+	assert := assert.New(t)
+
+	req, err := http.NewRequest(http.MethodGet, "http://localhost/company/00000001", nil)
+	assert.NoError(err)
+
+	body := &testBody{Reader: strings.NewReader(`{"errors": [{"error": "company-profile-not-found", "type": "ch:service"}]}`)}
+
+	resp := &http.Response{
+		StatusCode: 404,
+		Header: http.Header{
+			"X-Ratelimit-Limit":  {"600"},
+			"X-Ratelimit-Remain": {"599"},
+			"X-Ratelimit-Reset":  {"1622548800"},
+			"X-Ratelimit-Window": {"5m"},
+		},
+		Body:    body,
+		Request: req,
+	}
+
+	apiErr := newAPIError(resp, ErrNotFound)
+
+	assert.True(body.closed)
+	assert.Equal(404, apiErr.StatusCode)
+	assert.Equal("GET", apiErr.Method)
+	assert.Equal("http://localhost/company/00000001", apiErr.URL)
+	assert.Equal(RateLimit{600, 599, time.Unix(1622548800, 0), time.Minute * 5}, apiErr.RateLimit)
+	assert.Equal([]APIErrorDetail{{Error: "company-profile-not-found", Type: "ch:service"}}, apiErr.Errors)
+	assert.Equal("GET http://localhost/company/00000001: 404 not found: company-profile-not-found", apiErr.Error())
+
+	var target *APIError
+
+	wrapped := fmt.Errorf("wrapped: %w", apiErr)
+
+	assert.ErrorIs(wrapped, ErrNotFound)
+	assert.True(errors.As(wrapped, &target))
+	assert.Same(apiErr, target)
+}
+
+func TestNewAPIErrorInvalidBody(t *testing.T) {
+	assert := assert.New(t)
+
+	body := &testBody{Reader: strings.NewReader("<html>Bad Gateway</html>")}
+
+	apiErr := newAPIError(&http.Response{StatusCode: 502, Body: body}, ErrUnexpectedStatus)
+
+	assert.True(body.closed)
+	assert.Empty(apiErr.Errors)
+	assert.Equal("502 unexpected status", apiErr.Error())
+}
+
+func TestClientReturnsAPIError(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(400)
+		fmt.Fprint(w, `{"errors": [{"error": "invalid-query", "error_values": {"q": "?"}, "location": "q", "location_type": "request-parameter", "type": "ch:validation"}]}`)
+	})
+
+	defer ts.Close()
+
+	_, err := c.Get("/search?q=%3F")
+
+	var apiErr *APIError
+
+	if assert.True(errors.As(err, &apiErr)) {
+		assert.ErrorIs(err, ErrUnexpectedStatus)
+		assert.Equal(400, apiErr.StatusCode)
+		assert.Equal(ts.URL+"/search?q=%3F", apiErr.URL)
+		assert.Equal([]APIErrorDetail{{
+			Error:        "invalid-query",
+			ErrorValues:  map[string]string{"q": "?"},
+			Location:     "q",
+			LocationType: "request-parameter",
+			Type:         "ch:validation",
+		}}, apiErr.Errors)
+	}
+}
@@ -9,10 +9,7 @@ import (
 )
 
 func main() {
-	c := comphouse.NewClient(
-		"api.company-information.service.gov.uk",
-		comphouse.APIKey(os.Getenv("CH_API_KEY")),
-	)
+	c := comphouse.New(comphouse.APIKey(os.Getenv("CH_API_KEY")))
 
 	results, err := c.Search().Companies(comphouse.SearchParams{
 		Query:        "Subway",
@@ -24,12 +21,7 @@ func main() {
 	}
 
 	for _, result := range results.Items {
-		companyNo, err := comphouse.CompanyNumberFromString(result.CompanyNumber)
-		if err != nil {
-			log.Fatalln(err)
-		}
-
-		company := c.Company(companyNo)
+		company := c.Company(result.CompanyNumber)
 
 		profile, err := company.Profile()
 		if err != nil {
@@ -37,9 +29,7 @@ func main() {
 		}
 
 		fmt.Printf("[%s] %s\n", profile.CompanyNumber, profile.CompanyName)
-		fmt.Printf("  Address 1:   %s\n", profile.RegisteredOfficeAddress.AddressLine1)
-		fmt.Printf("  Town / City: %s\n", profile.RegisteredOfficeAddress.Locality)
-		fmt.Printf("  Postcode:    %s\n", profile.RegisteredOfficeAddress.PostalCode)
+		fmt.Printf("  Address:     %s\n", profile.RegisteredOfficeAddress)
 
 		fmt.Println("  SIC Codes:")
 		for _, sic := range profile.SicCodes {
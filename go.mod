@@ -4,5 +4,5 @@ go 1.16
 
 require (
 	github.com/kr/pretty v0.3.0 // indirect
-	github.com/stretchr/testify v1.7.0 // indirect
+	github.com/stretchr/testify v1.7.0
 )
@@ -0,0 +1,170 @@
+package comphouse
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// pager implements the pagination shared by all iterators. It fetches pages
+// of results on demand using fetch, which is responsible for storing the
+// page's items so the embedding iterator can return them from Item
+type pager struct {
+	// MaxResults caps the number of items the iterator will return. Zero
+	// means all available results are returned. It should be set before the
+	// first call to Next
+	MaxResults int
+
+	ctx   context.Context
+	fetch func(ctx context.Context, startIndex int) (size, total int, err error)
+	start int
+	index int
+	size  int
+	count int
+	last  bool
+	done  bool
+	err   error
+}
+
+func newPager(ctx context.Context, startIndex int, fetch func(context.Context, int) (int, int, error)) pager {
+	return pager{
+		ctx:   ctx,
+		fetch: fetch,
+		start: startIndex,
+	}
+}
+
+// Next advances the iterator to the next item, fetching the next page of
+// results when the current one is exhausted. It returns false once there are
+// no more results, MaxResults has been reached, the API refuses to paginate
+// any deeper or an error occurs, in which case it is returned by Err
+func (m *pager) Next() bool {
+	if m.done {
+		return false
+	}
+
+	if m.MaxResults > 0 && m.count >= m.MaxResults {
+		m.done = true
+		return false
+	}
+
+	if m.index++; m.index >= m.size {
+		if m.last {
+			m.done = true
+			return false
+		}
+
+		size, total, err := m.fetch(m.ctx, m.start)
+		if err != nil {
+			m.done = true
+
+			if !isPaginationLimit(err) {
+				m.err = err
+			}
+
+			return false
+		}
+
+		if size == 0 {
+			m.done = true
+			return false
+		}
+
+		m.index, m.size = 0, size
+		m.start += size
+
+		// some lists don't report a total so keep going until an empty page
+		m.last = total > 0 && m.start >= total
+	}
+
+	m.count++
+
+	return true
+}
+
+// Err returns the error that stopped the iterator, if any
+func (m *pager) Err() error {
+	return m.err
+}
+
+// isPaginationLimit reports whether err was caused by requesting a page past
+// the deepest one the API is willing to return
+func isPaginationLimit(err error) bool {
+	var apiErr *APIError
+
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable
+}
+
+// SearchIterator iterates over every result of a search across companies,
+// officers and disqualified officers
+type SearchIterator struct {
+	pager
+	items []SearchItem
+}
+
+// Item returns the current result. It must only be called after a call to
+// Next has returned true
+func (m *SearchIterator) Item() SearchItem {
+	return m.items[m.index]
+}
+
+// CompanySearchIterator iterates over every result of a company search
+type CompanySearchIterator struct {
+	pager
+	items []CompanySearchItem
+}
+
+// Item returns the current result. It must only be called after a call to
+// Next has returned true
+func (m *CompanySearchIterator) Item() CompanySearchItem {
+	return m.items[m.index]
+}
+
+// OfficerSearchIterator iterates over every result of an officer search
+type OfficerSearchIterator struct {
+	pager
+	items []OfficerSearchItem
+}
+
+// Item returns the current result. It must only be called after a call to
+// Next has returned true
+func (m *OfficerSearchIterator) Item() OfficerSearchItem {
+	return m.items[m.index]
+}
+
+// DisqualifiedOfficerSearchIterator iterates over every result of a
+// disqualified officer search
+type DisqualifiedOfficerSearchIterator struct {
+	pager
+	items []DisqualifiedOfficerSearchItem
+}
+
+// Item returns the current result. It must only be called after a call to
+// Next has returned true
+func (m *DisqualifiedOfficerSearchIterator) Item() DisqualifiedOfficerSearchItem {
+	return m.items[m.index]
+}
+
+// OfficerListIterator iterates over every officer of a company
+type OfficerListIterator struct {
+	pager
+	items []OfficerListItem
+}
+
+// Item returns the current officer. It must only be called after a call to
+// Next has returned true
+func (m *OfficerListIterator) Item() OfficerListItem {
+	return m.items[m.index]
+}
+
+// ChargeListIterator iterates over every charge registered against a company
+type ChargeListIterator struct {
+	pager
+	items []ChargeListItem
+}
+
+// Item returns the current charge. It must only be called after a call to
+// Next has returned true
+func (m *ChargeListIterator) Item() ChargeListItem {
+	return m.items[m.index]
+}
@@ -0,0 +1,242 @@
+package comphouse
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestPagedServer serves total numbered results two at a time. Pages
+// starting at or beyond limit are refused with a 416 as the search API does
+func createTestPagedServer(total, limit int, requests *[]int) (*httptest.Server, *Client) {
+	return createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start_index"))
+		*requests = append(*requests, start)
+
+		if limit > 0 && start >= limit {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+
+		items := []map[string]interface{}{}
+
+		for i := start; i < start+2 && i < total; i++ {
+			items = append(items, map[string]interface{}{
+				"title":          fmt.Sprintf("Result %d", i),
+				"company_number": fmt.Sprintf("%08d", i+1),
+				"name":           fmt.Sprintf("Officer %d", i),
+				"charge_number":  i,
+			})
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items":         items,
+			"start_index":   start,
+			"total_results": total,
+			"total_count":   total,
+		})
+	})
+}
+
+func TestSearchIteratorFetchesAllPages(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []int
+
+	ts, c := createTestPagedServer(5, 0, &requests)
+	defer ts.Close()
+
+	var titles []string
+
+	it := c.Search().CompaniesIter(SearchParams{Query: "test"})
+
+	for it.Next() {
+		titles = append(titles, it.Item().Title)
+	}
+
+	assert.NoError(it.Err())
+	assert.Equal([]string{"Result 0", "Result 1", "Result 2", "Result 3", "Result 4"}, titles)
+	assert.Equal([]int{0, 2, 4}, requests)
+	assert.False(it.Next())
+}
+
+func TestSearchIteratorStartIndex(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []int
+
+	ts, c := createTestPagedServer(5, 0, &requests)
+	defer ts.Close()
+
+	var titles []string
+
+	it := c.Search().AllIter(SearchParams{Query: "test", StartIndex: 3})
+
+	for it.Next() {
+		titles = append(titles, it.Item().Title)
+	}
+
+	assert.NoError(it.Err())
+	assert.Equal([]string{"Result 3", "Result 4"}, titles)
+	assert.Equal([]int{3}, requests)
+}
+
+func TestSearchIteratorMaxResults(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []int
+
+	ts, c := createTestPagedServer(100, 0, &requests)
+	defer ts.Close()
+
+	var titles []string
+
+	it := c.Search().OfficersIter(SearchParams{Query: "test"})
+	it.MaxResults = 3
+
+	for it.Next() {
+		titles = append(titles, it.Item().Title)
+	}
+
+	assert.NoError(it.Err())
+	assert.Equal([]string{"Result 0", "Result 1", "Result 2"}, titles)
+	assert.Equal([]int{0, 2}, requests)
+}
+
+func TestSearchIteratorStopsAtPaginationLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []int
+
+	ts, c := createTestPagedServer(100, 4, &requests)
+	defer ts.Close()
+
+	count := 0
+
+	it := c.Search().DisqualifiedOfficersIter(SearchParams{Query: "test"})
+
+	for it.Next() {
+		count++
+	}
+
+	assert.NoError(it.Err())
+	assert.Equal(4, count)
+	assert.Equal([]int{0, 2, 4}, requests)
+}
+
+func TestSearchIteratorStopsAtEmptyPage(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		// the total claims more results than will ever be returned
+		if requests == 1 {
+			fmt.Fprint(w, `{"items": [{"title": "Result 0"}], "total_results": 1000}`)
+			return
+		}
+
+		fmt.Fprint(w, `{"items": [], "total_results": 1000}`)
+	})
+
+	defer ts.Close()
+
+	count := 0
+
+	it := c.Search().CompaniesIter(SearchParams{Query: "test"})
+
+	for it.Next() {
+		count++
+	}
+
+	assert.NoError(it.Err())
+	assert.Equal(1, count)
+	assert.Equal(2, requests)
+}
+
+func TestSearchIteratorReturnsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(401)
+	})
+
+	defer ts.Close()
+
+	it := c.Search().CompaniesIter(SearchParams{Query: "test"})
+
+	assert.False(it.Next())
+	assert.ErrorIs(it.Err(), ErrUnauthorized)
+	assert.False(it.Next())
+}
+
+func TestSearchIteratorRespectsContext(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []int
+
+	ts, c := createTestPagedServer(5, 0, &requests)
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	it := c.Search().CompaniesIterContext(ctx, SearchParams{Query: "test"})
+
+	assert.True(it.Next())
+	assert.True(it.Next())
+
+	cancel()
+
+	assert.False(it.Next())
+	assert.Same(context.Canceled, it.Err())
+}
+
+func TestCompanyEndpointOfficersIter(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []int
+
+	ts, c := createTestPagedServer(3, 0, &requests)
+	defer ts.Close()
+
+	var names []string
+
+	it := c.Company(EnglishCompanyNo(1)).OfficersIter()
+
+	for it.Next() {
+		names = append(names, it.Item().Name)
+	}
+
+	assert.NoError(it.Err())
+	assert.Equal([]string{"Officer 0", "Officer 1", "Officer 2"}, names)
+	assert.Equal([]int{0, 2}, requests)
+}
+
+func TestCompanyEndpointChargesIter(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests []int
+
+	ts, c := createTestPagedServer(3, 0, &requests)
+	defer ts.Close()
+
+	var numbers []int
+
+	it := c.Company(EnglishCompanyNo(1)).ChargesIter()
+
+	for it.Next() {
+		numbers = append(numbers, it.Item().ChargeNumber)
+	}
+
+	assert.NoError(it.Err())
+	assert.Equal([]int{0, 1, 2}, numbers)
+	assert.Equal([]int{0, 2}, requests)
+}
@@ -0,0 +1,185 @@
+package comphouse
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LogLevel is the severity of a log entry
+type LogLevel int
+
+// Log levels in increasing order of severity
+const (
+	LogLevelDebug LogLevel = iota
+	LogLevelInfo
+	LogLevelWarn
+	LogLevelError
+)
+
+// String returns the lower case name of the level
+func (m LogLevel) String() string {
+	switch m {
+	case LogLevelDebug:
+		return "debug"
+	case LogLevelInfo:
+		return "info"
+	case LogLevelWarn:
+		return "warn"
+	case LogLevelError:
+		return "error"
+	}
+
+	return "level(" + strconv.Itoa(int(m)) + ")"
+}
+
+// LogField is a key value pair attached to a log entry
+type LogField struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger is implemented by types that can record a Client's traffic. It is
+// small enough to adapt to any structured logging library. Implementations
+// must be safe for concurrent use
+type Logger interface {
+	Log(level LogLevel, msg string, fields ...LogField)
+}
+
+// StdLogger is a Logger that writes logfmt style lines to a *log.Logger
+type StdLogger struct {
+	Logger *log.Logger
+	Level  LogLevel
+}
+
+// NewStdLogger creates a new StdLogger that writes entries of at least the
+// specified level to l. A nil l writes to the standard logger
+func NewStdLogger(l *log.Logger, level LogLevel) *StdLogger {
+	if l == nil {
+		l = log.Default()
+	}
+
+	return &StdLogger{Logger: l, Level: level}
+}
+
+// Log writes an entry if its level is at least the StdLogger's level
+func (m *StdLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	if level < m.Level {
+		return
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "level=%s msg=%q", level, msg)
+
+	for _, f := range fields {
+		v := fmt.Sprint(f.Value)
+
+		if strings.ContainsAny(v, " \t\n\"=") || v == "" {
+			v = strconv.Quote(v)
+		}
+
+		fmt.Fprintf(&b, " %s=%s", f.Key, v)
+	}
+
+	m.Logger.Print(b.String())
+}
+
+// headers that must never be written to logs as they hold credentials
+var sensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// redactHeader returns a copy of header with credentials replaced
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+
+	for _, key := range sensitiveHeaders {
+		if _, ok := header[key]; ok {
+			header[key] = []string{"REDACTED"}
+		}
+	}
+
+	return header
+}
+
+// redactURL returns u as a string with any user information removed
+func redactURL(u *url.URL) string {
+	if u.User == nil {
+		return u.String()
+	}
+
+	redacted := *u
+	redacted.User = url.User("REDACTED")
+
+	return redacted.String()
+}
+
+// companyNumberFromPath extracts the company number from company paths such
+// as /company/00000001/officers
+func companyNumberFromPath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(segments) >= 2 && segments[0] == "company" {
+		return segments[1]
+	}
+
+	return ""
+}
+
+// logAttempt logs a single attempt of a request. Failed attempts are logged
+// with the request's headers, which are redacted so that API keys never
+// appear in logs
+func (m *Client) logAttempt(req *http.Request, resp *http.Response, err error, attempt int, duration, retryIn time.Duration, retry bool) {
+	if m.Logger == nil {
+		return
+	}
+
+	fields := []LogField{
+		{"method", req.Method},
+		{"path", req.URL.Path},
+	}
+
+	if companyNo := companyNumberFromPath(req.URL.Path); companyNo != "" {
+		fields = append(fields, LogField{"company_number", companyNo})
+	}
+
+	level := LogLevelInfo
+
+	if err != nil {
+		level = LogLevelError
+		fields = append(fields, LogField{"error", strings.ReplaceAll(err.Error(), req.URL.String(), redactURL(req.URL))})
+	} else {
+		fields = append(fields, LogField{"status", resp.StatusCode})
+
+		if resp.StatusCode >= 400 {
+			level = LogLevelWarn
+		}
+	}
+
+	fields = append(fields, LogField{"duration", duration}, LogField{"attempt", attempt})
+
+	if resp != nil && resp.Header.Get("X-Ratelimit-Remain") != "" {
+		fields = append(fields, LogField{"rate_limit_remaining", RateLimitFromHeader(resp.Header).Remain})
+	} else if m.Limiter != nil {
+		fields = append(fields, LogField{"rate_limit_remaining", m.Limiter.Remaining()})
+	}
+
+	if retry {
+		level = LogLevelWarn
+		fields = append(fields, LogField{"retry_in", retryIn})
+	}
+
+	if level > LogLevelInfo {
+		fields = append(fields, LogField{"request_headers", redactHeader(req.Header)})
+	}
+
+	m.Logger.Log(level, "request", fields...)
+}
@@ -0,0 +1,158 @@
+package comphouse
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLogEntry struct {
+	level  LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+type testLogger struct {
+	mu      sync.Mutex
+	entries []testLogEntry
+}
+
+func (m *testLogger) Log(level LogLevel, msg string, fields ...LogField) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := testLogEntry{level: level, msg: msg, fields: map[string]interface{}{}}
+
+	for _, f := range fields {
+		e.fields[f.Key] = f.Value
+	}
+
+	m.entries = append(m.entries, e)
+}
+
+func TestClientLogsRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Ratelimit-Remain", "599")
+		w.Write([]byte(`{}`))
+	})
+
+	defer ts.Close()
+
+	l := &testLogger{}
+	c.Logger = l
+
+	_, err := c.Company(EnglishCompanyNo(1)).Officers()
+
+	assert.NoError(err)
+	assert.Len(l.entries, 1)
+
+	e := l.entries[0]
+
+	assert.Equal(LogLevelInfo, e.level)
+	assert.Equal("request", e.msg)
+	assert.Equal("GET", e.fields["method"])
+	assert.Equal("/company/00000001/officers", e.fields["path"])
+	assert.Equal("00000001", e.fields["company_number"])
+	assert.Equal(200, e.fields["status"])
+	assert.Equal(1, e.fields["attempt"])
+	assert.Equal(599, e.fields["rate_limit_remaining"])
+	assert.IsType(time.Duration(0), e.fields["duration"])
+	assert.NotContains(e.fields, "request_headers")
+}
+
+func TestClientLogsRetriesWithRedactedHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		if requests++; requests == 1 {
+			w.WriteHeader(503)
+			return
+		}
+
+		w.WriteHeader(404)
+	})
+
+	defer ts.Close()
+
+	l := &testLogger{}
+
+	c.Auth = APIKey("secret-key")
+	c.Logger = l
+	c.Retry = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	_, err := c.Get("/search/companies")
+
+	assert.ErrorIs(err, ErrNotFound)
+	assert.Len(l.entries, 2)
+
+	assert.Equal(LogLevelWarn, l.entries[0].level)
+	assert.Equal(503, l.entries[0].fields["status"])
+	assert.Contains(l.entries[0].fields, "retry_in")
+	assert.NotContains(l.entries[0].fields, "company_number")
+
+	assert.Equal(LogLevelWarn, l.entries[1].level)
+	assert.Equal(2, l.entries[1].fields["attempt"])
+
+	for _, e := range l.entries {
+		header := e.fields["request_headers"].(http.Header)
+		assert.Equal("REDACTED", header.Get("Authorization"))
+	}
+
+	req, _ := c.NewRequest("GET", "", nil)
+	assert.NotEqual("REDACTED", req.Header.Get("Authorization"))
+}
+
+func TestClientLogsTransportErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(http.ResponseWriter, *http.Request) {})
+	ts.Close()
+
+	l := &testLogger{}
+
+	c.Logger = l
+	c.Retry = nil
+
+	_, err := c.Get("")
+
+	assert.Error(err)
+	assert.Len(l.entries, 1)
+	assert.Equal(LogLevelError, l.entries[0].level)
+	assert.Contains(l.entries[0].fields, "error")
+	assert.NotContains(l.entries[0].fields, "status")
+}
+
+func TestStdLogger(t *testing.T) {
+	assert := assert.New(t)
+
+	var buff bytes.Buffer
+
+	l := NewStdLogger(log.New(&buff, "", 0), LogLevelInfo)
+
+	l.Log(LogLevelDebug, "ignored")
+	l.Log(LogLevelWarn, "request", LogField{"path", "/company/00000001"}, LogField{"error", "not found"}, LogField{"status", 404})
+
+	assert.Equal("level=warn msg=\"request\" path=/company/00000001 error=\"not found\" status=404\n", buff.String())
+}
+
+func TestRedactURL(t *testing.T) {
+	assert := assert.New(t)
+
+	u, _ := url.Parse("https://key:@localhost/company/00000001")
+
+	assert.Equal("https://REDACTED@localhost/company/00000001", redactURL(u))
+
+	u, _ = url.Parse("https://localhost/company/00000001")
+
+	assert.Equal("https://localhost/company/00000001", redactURL(u))
+}
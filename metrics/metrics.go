@@ -0,0 +1,308 @@
+// Package metrics instruments a comphouse.Client with counters, histograms
+// and gauges that can be rendered in the Prometheus text exposition format
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/johnfrankmorgan/comphouse"
+)
+
+// DefaultBuckets are the upper bounds, in seconds, of the request duration
+// histogram used when creating a new Collector
+var DefaultBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Names of the metrics recorded by a Collector
+const (
+	RequestsTotal      = "comphouse_requests_total"
+	RequestDuration    = "comphouse_request_duration_seconds"
+	RetriesTotal       = "comphouse_retries_total"
+	CacheRequestsTotal = "comphouse_cache_requests_total"
+	RateLimitRemaining = "comphouse_rate_limit_remaining"
+)
+
+// Cache results recorded by a Collector. A stale result means a cached
+// response was found but had to be revalidated with the API
+const (
+	CacheHit   = "hit"
+	CacheStale = "stale"
+	CacheMiss  = "miss"
+)
+
+// endpoint label used for paths that don't belong to a known endpoint
+const otherEndpoint = "other"
+
+type requestKey struct {
+	endpoint string
+	method   string
+	status   string
+}
+
+type histogram struct {
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// Collector records metrics for the requests made by one or more Clients. A
+// Collector is safe for concurrent use
+type Collector struct {
+	mu        sync.Mutex
+	buckets   []float64
+	requests  map[requestKey]uint64
+	durations map[string]*histogram
+	retries   map[string]uint64
+	cache     map[string]uint64
+	remaining float64
+	now       func() time.Time
+}
+
+// New creates a new Collector using DefaultBuckets for its request duration
+// histogram
+func New() *Collector {
+	return NewWithBuckets(DefaultBuckets)
+}
+
+// NewWithBuckets creates a new Collector using the provided upper bounds, in
+// seconds, for its request duration histogram
+func NewWithBuckets(buckets []float64) *Collector {
+	b := append([]float64(nil), buckets...)
+	sort.Float64s(b)
+
+	return &Collector{
+		buckets:   b,
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[string]*histogram),
+		retries:   make(map[string]uint64),
+		cache:     make(map[string]uint64),
+		remaining: math.NaN(),
+		now:       time.Now,
+	}
+}
+
+// Instrument adds the Collector's middleware to a Client and wraps its Cache,
+// if it has one, so that cache lookups are recorded. Instrument should be
+// called after the Client's Cache has been configured
+func (m *Collector) Instrument(c *comphouse.Client) {
+	c.Use(m.Middleware())
+
+	if c.Cache != nil {
+		c.Cache = m.Cache(c.Cache)
+	}
+}
+
+// Middleware returns a comphouse.Middleware recording the endpoint, status
+// and duration of every attempt of a request, retries and the rate limit
+// remaining reported by the API
+func (m *Collector) Middleware() comphouse.Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return comphouse.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := m.now()
+
+			resp, err := next.RoundTrip(req)
+
+			m.observe(req, resp, err, m.now().Sub(start))
+
+			return resp, err
+		})
+	}
+}
+
+// observe records a single attempt of a request
+func (m *Collector) observe(req *http.Request, resp *http.Response, err error, d time.Duration) {
+	endpoint := comphouse.EndpointTemplate(req.URL.Path)
+	if endpoint == "" {
+		endpoint = otherEndpoint
+	}
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{endpoint, req.Method, status}]++
+
+	h, ok := m.durations[endpoint]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.durations[endpoint] = h
+	}
+
+	seconds := d.Seconds()
+
+	for i, upper := range m.buckets {
+		if seconds <= upper {
+			h.counts[i]++
+		}
+	}
+
+	h.count++
+	h.sum += seconds
+
+	if comphouse.AttemptFromContext(req.Context()) > 1 {
+		m.retries[endpoint]++
+	}
+
+	if resp != nil {
+		if remain := resp.Header.Get("X-Ratelimit-Remain"); remain != "" {
+			if v, err := strconv.ParseFloat(remain, 64); err == nil {
+				m.remaining = v
+			}
+		}
+	}
+}
+
+// Cache wraps a comphouse.Cache so that each lookup is recorded as a hit,
+// stale or miss
+func (m *Collector) Cache(c comphouse.Cache) comphouse.Cache {
+	return &cache{Cache: c, collector: m}
+}
+
+type cache struct {
+	comphouse.Cache
+	collector *Collector
+}
+
+func (m *cache) Get(key string) (*comphouse.CachedResponse, bool) {
+	resp, ok := m.Cache.Get(key)
+
+	result := CacheMiss
+
+	if ok {
+		result = CacheStale
+
+		if m.collector.now().Before(resp.Expires) {
+			result = CacheHit
+		}
+	}
+
+	m.collector.mu.Lock()
+	m.collector.cache[result]++
+	m.collector.mu.Unlock()
+
+	return resp, ok
+}
+
+// WritePrometheus renders the recorded metrics in the Prometheus text
+// exposition format. Series are sorted so the output is stable
+func (m *Collector) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	header := func(name, kind, help string) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+	}
+
+	header(RequestsTotal, "counter", "Requests sent to the Companies House API by endpoint, method and status.")
+
+	requests := make([]requestKey, 0, len(m.requests))
+	for k := range m.requests {
+		requests = append(requests, k)
+	}
+
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i], requests[j]
+
+		if a.endpoint != b.endpoint {
+			return a.endpoint < b.endpoint
+		}
+
+		if a.method != b.method {
+			return a.method < b.method
+		}
+
+		return a.status < b.status
+	})
+
+	for _, k := range requests {
+		fmt.Fprintf(&b, "%s{endpoint=%s,method=%s,status=%s} %d\n", RequestsTotal, quote(k.endpoint), quote(k.method), quote(k.status), m.requests[k])
+	}
+
+	header(RequestDuration, "histogram", "Duration of requests sent to the Companies House API by endpoint.")
+
+	for _, endpoint := range sortedKeys(m.durations) {
+		h := m.durations[endpoint]
+
+		for i, upper := range m.buckets {
+			fmt.Fprintf(&b, "%s_bucket{endpoint=%s,le=%s} %d\n", RequestDuration, quote(endpoint), quote(formatFloat(upper)), h.counts[i])
+		}
+
+		fmt.Fprintf(&b, "%s_bucket{endpoint=%s,le=\"+Inf\"} %d\n", RequestDuration, quote(endpoint), h.count)
+		fmt.Fprintf(&b, "%s_sum{endpoint=%s} %s\n", RequestDuration, quote(endpoint), formatFloat(h.sum))
+		fmt.Fprintf(&b, "%s_count{endpoint=%s} %d\n", RequestDuration, quote(endpoint), h.count)
+	}
+
+	header(RetriesTotal, "counter", "Requests to the Companies House API that were retried by endpoint.")
+
+	for _, endpoint := range sortedKeys(m.retries) {
+		fmt.Fprintf(&b, "%s{endpoint=%s} %d\n", RetriesTotal, quote(endpoint), m.retries[endpoint])
+	}
+
+	header(CacheRequestsTotal, "counter", "Response cache lookups by result.")
+
+	for _, result := range sortedKeys(m.cache) {
+		fmt.Fprintf(&b, "%s{result=%s} %d\n", CacheRequestsTotal, quote(result), m.cache[result])
+	}
+
+	header(RateLimitRemaining, "gauge", "Requests remaining in the current rate limit window as reported by the API.")
+
+	if !math.IsNaN(m.remaining) {
+		fmt.Fprintf(&b, "%s %s\n", RateLimitRemaining, formatFloat(m.remaining))
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// ServeHTTP writes the recorded metrics in the Prometheus text exposition
+// format so a Collector can be mounted on an existing metrics endpoint
+func (m *Collector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+// sortedKeys returns the keys of a map with string keys in sorted order
+func sortedKeys(m interface{}) []string {
+	var keys []string
+
+	switch m := m.(type) {
+	case map[string]uint64:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	case map[string]*histogram:
+		for k := range m {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// quote formats a label value, escaping it as required by the exposition
+// format
+func quote(v string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+	return `"` + r.Replace(v) + `"`
+}
+
+// formatFloat formats a sample value or bucket bound
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
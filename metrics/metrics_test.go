@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/johnfrankmorgan/comphouse"
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestCollector creates a Collector whose clock advances by step every
+// time it is read, so every request appears to take step to complete
+func createTestCollector(step time.Duration) *Collector {
+	var mu sync.Mutex
+
+	now := time.Now()
+
+	c := NewWithBuckets([]float64{0.1, 1})
+	c.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		now = now.Add(step)
+		return now
+	}
+
+	return c
+}
+
+func createTestClient(handler http.HandlerFunc) (*httptest.Server, *comphouse.Client) {
+	ts := httptest.NewServer(handler)
+
+	c := comphouse.NewClient(ts.Listener.Addr().String(), nil)
+	c.Protocol = "http"
+	c.Retry = &comphouse.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	return ts, c
+}
+
+func TestCollector(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	ts, c := createTestClient(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		w.Header().Set("X-Ratelimit-Remain", "597")
+
+		if strings.HasPrefix(r.URL.Path, "/search") && requests == 2 {
+			w.WriteHeader(503)
+			return
+		}
+
+		if r.URL.Path == "/company/00000002" {
+			w.WriteHeader(404)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"abc"`)
+		w.Write([]byte(`{}`))
+	})
+
+	defer ts.Close()
+
+	c.Cache = comphouse.NewLRUCache(10)
+	c.CachePolicy.TTL = time.Hour
+
+	m := createTestCollector(time.Millisecond * 250)
+	m.Instrument(c)
+
+	_, err := c.Company(comphouse.EnglishCompanyNo(1)).Profile()
+	assert.NoError(err)
+
+	_, err = c.Search().Companies(comphouse.SearchParams{Query: "test"})
+	assert.NoError(err)
+
+	_, err = c.Company(comphouse.EnglishCompanyNo(2)).Profile()
+	assert.ErrorIs(err, comphouse.ErrNotFound)
+
+	_, err = c.Company(comphouse.EnglishCompanyNo(1)).Profile()
+	assert.NoError(err)
+
+	var buff bytes.Buffer
+
+	assert.NoError(m.WritePrometheus(&buff))
+	assert.Equal(4, requests)
+	assert.Equal(`# HELP comphouse_requests_total Requests sent to the Companies House API by endpoint, method and status.
+# TYPE comphouse_requests_total counter
+comphouse_requests_total{endpoint="/company/{company_number}",method="GET",status="200"} 1
+comphouse_requests_total{endpoint="/company/{company_number}",method="GET",status="404"} 1
+comphouse_requests_total{endpoint="/search/companies",method="GET",status="200"} 1
+comphouse_requests_total{endpoint="/search/companies",method="GET",status="503"} 1
+# HELP comphouse_request_duration_seconds Duration of requests sent to the Companies House API by endpoint.
+# TYPE comphouse_request_duration_seconds histogram
+comphouse_request_duration_seconds_bucket{endpoint="/company/{company_number}",le="0.1"} 0
+comphouse_request_duration_seconds_bucket{endpoint="/company/{company_number}",le="1"} 2
+comphouse_request_duration_seconds_bucket{endpoint="/company/{company_number}",le="+Inf"} 2
+comphouse_request_duration_seconds_sum{endpoint="/company/{company_number}"} 0.5
+comphouse_request_duration_seconds_count{endpoint="/company/{company_number}"} 2
+comphouse_request_duration_seconds_bucket{endpoint="/search/companies",le="0.1"} 0
+comphouse_request_duration_seconds_bucket{endpoint="/search/companies",le="1"} 2
+comphouse_request_duration_seconds_bucket{endpoint="/search/companies",le="+Inf"} 2
+comphouse_request_duration_seconds_sum{endpoint="/search/companies"} 0.5
+comphouse_request_duration_seconds_count{endpoint="/search/companies"} 2
+# HELP comphouse_retries_total Requests to the Companies House API that were retried by endpoint.
+# TYPE comphouse_retries_total counter
+comphouse_retries_total{endpoint="/search/companies"} 1
+# HELP comphouse_cache_requests_total Response cache lookups by result.
+# TYPE comphouse_cache_requests_total counter
+comphouse_cache_requests_total{result="hit"} 1
+comphouse_cache_requests_total{result="miss"} 3
+# HELP comphouse_rate_limit_remaining Requests remaining in the current rate limit window as reported by the API.
+# TYPE comphouse_rate_limit_remaining gauge
+comphouse_rate_limit_remaining 597
+`, buff.String())
+}
+
+func TestCollectorRecordsTransportErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestClient(func(http.ResponseWriter, *http.Request) {})
+	ts.Close()
+
+	c.Retry = nil
+
+	m := New()
+	m.Instrument(c)
+
+	_, err := c.Get("/unknown")
+	assert.Error(err)
+
+	var buff bytes.Buffer
+
+	assert.NoError(m.WritePrometheus(&buff))
+	assert.Contains(buff.String(), `comphouse_requests_total{endpoint="other",method="GET",status="error"} 1`)
+	assert.NotContains(buff.String(), "\ncomphouse_rate_limit_remaining ")
+}
+
+func TestCollectorServeHTTP(t *testing.T) {
+	assert := assert.New(t)
+
+	rec := httptest.NewRecorder()
+
+	New().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	assert.Equal(200, rec.Code)
+	assert.Equal("text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(rec.Body.String(), "# TYPE comphouse_requests_total counter")
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `"a\"b\\c\nd"`, quote("a\"b\\c\nd"))
+}
@@ -0,0 +1,19 @@
+package comphouse
+
+import "net/http"
+
+// Middleware wraps the http.RoundTripper used to send requests, allowing
+// requests and responses to be inspected, modified or replaced. Middleware is
+// executed for every attempt of a request, so it observes retries, and it may
+// short-circuit a request by returning without calling next. Transport errors
+// are returned from next and can be observed or replaced too
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as
+// http.RoundTrippers
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
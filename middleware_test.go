@@ -0,0 +1,157 @@
+package comphouse
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			*calls = append(*calls, "before "+name)
+
+			resp, err := next.RoundTrip(req)
+
+			*calls = append(*calls, "after "+name)
+
+			return resp, err
+		})
+	}
+}
+
+func TestClientMiddlewareOrder(t *testing.T) {
+	assert := assert.New(t)
+
+	var calls []string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		calls = append(calls, "server")
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	c.Hooks.BeforeRequest = append(c.Hooks.BeforeRequest, func(*http.Request) {
+		calls = append(calls, "before hook")
+	})
+
+	c.Hooks.AfterRequest = append(c.Hooks.AfterRequest, func(*http.Response) {
+		calls = append(calls, "after hook")
+	})
+
+	c.Use(recordingMiddleware("first", &calls), recordingMiddleware("second", &calls))
+
+	_, err := c.Get("")
+
+	assert.NoError(err)
+	assert.Equal([]string{
+		"before hook",
+		"before first",
+		"before second",
+		"server",
+		"after second",
+		"after first",
+		"after hook",
+	}, calls)
+}
+
+func TestClientMiddlewareShortCircuits(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+	})
+
+	defer ts.Close()
+
+	c.Use(func(http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: 200,
+				Header:     http.Header{},
+				Body:       io.NopCloser(bytes.NewBufferString(`{"company_name": "Stubbed"}`)),
+				Request:    req,
+			}, nil
+		})
+	})
+
+	profile, err := c.Company(EnglishCompanyNo(1)).Profile()
+
+	assert.NoError(err)
+	assert.Equal("Stubbed", profile.CompanyName)
+	assert.Zero(requests)
+}
+
+func TestClientMiddlewareObservesTransportErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {})
+	ts.Close()
+
+	c.Retry = nil
+
+	var (
+		observed   error
+		afterHooks int
+	)
+
+	c.Hooks.AfterRequest = append(c.Hooks.AfterRequest, func(*http.Response) {
+		afterHooks++
+	})
+
+	c.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			resp, err := next.RoundTrip(req)
+			observed = err
+			return resp, err
+		})
+	})
+
+	_, err := c.Get("")
+
+	assert.Error(err)
+	assert.Same(err, observed)
+	assert.Zero(afterHooks)
+}
+
+func TestClientMiddlewareRunsForEveryAttempt(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	c.Retry = &RetryPolicy{MaxAttempts: 3}
+
+	attempts := 0
+	injected := errors.New("injected fault")
+
+	// fail the first attempt to check the fault is retried
+	c.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if attempts++; attempts == 1 {
+				return nil, injected
+			}
+
+			return next.RoundTrip(req)
+		})
+	})
+
+	_, err := c.Get("")
+
+	assert.NoError(err)
+	assert.Equal(2, attempts)
+	assert.Equal(1, requests)
+}
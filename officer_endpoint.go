@@ -0,0 +1,84 @@
+package comphouse
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// OfficerEndpoint is a struct that can be used to query the appointments held
+// by an officer
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/officer-appointments
+type OfficerEndpoint struct {
+	Client *Client
+	ID     string
+}
+
+// AppointmentParams are used to filter and paginate an officer's appointments
+type AppointmentParams struct {
+	ItemsPerPage int
+	StartIndex   int
+	ActiveOnly   bool
+}
+
+// Encode converts AppointmentParams into an escaped string suitable for use in
+// query strings
+func (m AppointmentParams) Encode() string {
+	q := url.Values{}
+
+	if m.ItemsPerPage > 0 {
+		q.Set("items_per_page", strconv.Itoa(m.ItemsPerPage))
+	}
+
+	if m.StartIndex > 0 {
+		q.Set("start_index", strconv.Itoa(m.StartIndex))
+	}
+
+	if m.ActiveOnly {
+		q.Set("filter", "active")
+	}
+
+	return q.Encode()
+}
+
+// OfficerIDFromLink extracts an officer's ID from a link in the format used by
+// the Companies House API, such as the Links.Officer.Appointments field of
+// OfficerList items or the Links.Self field of OfficerSearch items
+func OfficerIDFromLink(link string) (string, error) {
+	parts := strings.Split(strings.Trim(link, "/"), "/")
+
+	if len(parts) < 2 || parts[0] != "officers" || parts[1] == "" {
+		return "", ErrInvalidOfficerLink
+	}
+
+	return parts[1], nil
+}
+
+// helper method to format a path for an officer
+func (m *OfficerEndpoint) path(extra ...string) string {
+	p := "/officers/" + m.ID
+
+	if len(extra) > 0 {
+		p += "/" + strings.Join(extra, "/")
+	}
+
+	return p
+}
+
+// List of all of an officer's appointments
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/officer-appointments/list
+func (m *OfficerEndpoint) Appointments(params AppointmentParams) (*AppointmentList, error) {
+	return m.AppointmentsContext(context.Background(), params)
+}
+
+// AppointmentsContext is the same as Appointments but uses the provided context
+func (m *OfficerEndpoint) AppointmentsContext(ctx context.Context, params AppointmentParams) (*AppointmentList, error) {
+	a := &AppointmentList{}
+
+	if err := m.Client.GetJSONContext(ctx, withQuery(m.path("appointments"), params.Encode()), a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
@@ -0,0 +1,118 @@
+package comphouse
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppointmentParamsEncode(t *testing.T) {
+	type test struct {
+		inp AppointmentParams
+		exp string
+	}
+
+	tests := []test{
+		{AppointmentParams{}, ""},
+		{AppointmentParams{ItemsPerPage: 25}, "items_per_page=25"},
+		{AppointmentParams{StartIndex: 50, ActiveOnly: true}, "filter=active&start_index=50"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(test.exp, test.inp.Encode())
+		})
+	}
+}
+
+func TestOfficerIDFromLink(t *testing.T) {
+	type test struct {
+		inp string
+		exp string
+		err error
+	}
+
+	tests := []test{
+		{"/officers/abc123/appointments", "abc123", nil},
+		{"/officers/abc123", "abc123", nil},
+		{"officers/abc123/appointments/", "abc123", nil},
+		{"/company/00000001/appointments/abc123", "", ErrInvalidOfficerLink},
+		{"/officers/", "", ErrInvalidOfficerLink},
+		{"", "", ErrInvalidOfficerLink},
+	}
+
+	for _, test := range tests {
+		t.Run(test.inp, func(t *testing.T) {
+			assert := assert.New(t)
+
+			id, err := OfficerIDFromLink(test.inp)
+
+			assert.Equal(test.exp, id)
+			assert.Equal(test.err, err)
+		})
+	}
+}
+
+func TestOfficerEndpointAppointments(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"name": "Jane Doe",
+			"total_results": 1,
+			"items": [{
+				"appointed_on": "2020-01-01",
+				"appointed_to": {"company_name": "Company Name", "company_number": "00000001"},
+				"officer_role": "director"
+			}]
+		}`)
+	})
+
+	defer ts.Close()
+
+	a, err := c.Officer("abc123").Appointments(AppointmentParams{ItemsPerPage: 10})
+
+	if assert.NoError(err) {
+		assert.Equal("/officers/abc123/appointments?items_per_page=10", uri)
+		assert.Equal("Jane Doe", a.Name)
+		assert.Equal(EnglishCompanyNo(1), a.Items[0].AppointedTo.CompanyNumber.Number())
+		assert.Equal("director", a.Items[0].OfficerRole)
+	}
+}
+
+func TestOfficerEndpointHandlesErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(404)
+	})
+
+	defer ts.Close()
+
+	a, err := c.Officer("abc123").Appointments(AppointmentParams{})
+
+	assert.Nil(a)
+	assert.ErrorIs(err, ErrNotFound)
+}
+
+func TestOfficerEndpointRespectsContext(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServerWithResponse(map[string]string{"name": "Jane Doe"})
+
+	defer ts.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.Officer("abc123").AppointmentsContext(ctx, AppointmentParams{})
+
+	assert.Same(context.Canceled, err)
+}
@@ -0,0 +1,193 @@
+package comphouse
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Default values used when creating a new RateLimiter for a Client. These
+// match the documented Companies House limit of 600 requests every 5 minutes
+const (
+	DefaultRateLimit       = 600
+	DefaultRateLimitWindow = time.Minute * 5
+)
+
+// RateLimit holds the rate limit information returned in the X-Ratelimit-*
+// headers of a Companies House API response. Fields are left as their zero
+// values if the corresponding header is missing or malformed
+type RateLimit struct {
+	Limit  int
+	Remain int
+	Reset  time.Time
+	Window time.Duration
+}
+
+// RateLimitFromHeader extracts rate limit information from response headers
+func RateLimitFromHeader(header http.Header) RateLimit {
+	var r RateLimit
+
+	r.Limit, _ = strconv.Atoi(header.Get("X-Ratelimit-Limit"))
+	r.Remain, _ = strconv.Atoi(header.Get("X-Ratelimit-Remain"))
+	r.Window, _ = time.ParseDuration(header.Get("X-Ratelimit-Window"))
+
+	if reset, err := strconv.ParseInt(header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+		r.Reset = time.Unix(reset, 0)
+	}
+
+	return r
+}
+
+// RateLimiter is a token bucket used to pace requests so that the Companies
+// House rate limit is never exceeded. The bucket is seeded from a fixed limit
+// and window and continuously corrected using the X-Ratelimit-* headers
+// returned by the API. A RateLimiter is safe for concurrent use and may be
+// shared between multiple Clients using the same API key
+type RateLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	tokens  float64
+	updated time.Time
+	resetAt time.Time
+	now     func() time.Time
+}
+
+// NewRateLimiter creates a new RateLimiter allowing limit requests in every
+// window. The bucket starts full. A non-positive limit or window disables
+// pacing altogether
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:  limit,
+		window: window,
+		tokens: float64(limit),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request can be made without exceeding the rate limit
+// and then consumes a token. It returns early with the context's error if the
+// context is done first. Calling Wait on a nil RateLimiter returns immediately
+func (m *RateLimiter) Wait(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+
+	for {
+		d := m.reserve()
+		if d <= 0 {
+			return nil
+		}
+
+		if err := sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+}
+
+// Remaining returns the number of requests that can currently be made before
+// the limiter starts delaying requests
+func (m *RateLimiter) Remaining() int {
+	if m == nil {
+		return math.MaxInt32
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.refill(m.now())
+
+	return int(m.tokens)
+}
+
+// Update corrects the limiter's state using the X-Ratelimit-Limit,
+// X-Ratelimit-Window, X-Ratelimit-Remain and X-Ratelimit-Reset headers from a
+// Companies House API response. Missing or malformed headers are ignored
+func (m *RateLimiter) Update(header http.Header) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.refill(now)
+
+	if limit, err := strconv.Atoi(header.Get("X-Ratelimit-Limit")); err == nil && limit > 0 {
+		m.limit = limit
+	}
+
+	if window, err := time.ParseDuration(header.Get("X-Ratelimit-Window")); err == nil && window > 0 {
+		m.window = window
+	}
+
+	if remain, err := strconv.Atoi(header.Get("X-Ratelimit-Remain")); err == nil && remain >= 0 {
+		m.tokens = math.Min(float64(remain), float64(m.limit))
+	}
+
+	if reset, err := strconv.ParseInt(header.Get("X-Ratelimit-Reset"), 10, 64); err == nil {
+		if t := time.Unix(reset, 0); t.After(now) {
+			m.resetAt = t
+		}
+	}
+}
+
+// reserve consumes a token if one is available, otherwise it returns how long
+// the caller should wait before trying again
+func (m *RateLimiter) reserve() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.limit <= 0 || m.window <= 0 {
+		return 0
+	}
+
+	now := m.now()
+	m.refill(now)
+
+	if m.tokens >= 1 {
+		m.tokens--
+		return 0
+	}
+
+	if !m.resetAt.IsZero() {
+		return m.resetAt.Sub(now)
+	}
+
+	return time.Duration(math.Ceil((1 - m.tokens) / m.rate()))
+}
+
+// refill adds the tokens accrued since the last refill. When the API has told
+// us when its window resets, the bucket is refilled in full at that point
+// instead, as the server won't replenish the budget before then
+func (m *RateLimiter) refill(now time.Time) {
+	defer func() {
+		m.updated = now
+	}()
+
+	if !m.resetAt.IsZero() {
+		if now.Before(m.resetAt) {
+			return
+		}
+
+		m.resetAt = time.Time{}
+		m.tokens = float64(m.limit)
+
+		return
+	}
+
+	if m.updated.IsZero() || m.limit <= 0 || m.window <= 0 {
+		return
+	}
+
+	m.tokens = math.Min(float64(m.limit), m.tokens+float64(now.Sub(m.updated))*m.rate())
+}
+
+// rate returns the number of tokens added to the bucket per nanosecond
+func (m *RateLimiter) rate() float64 {
+	return float64(m.limit) / float64(m.window)
+}
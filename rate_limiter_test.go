@@ -0,0 +1,178 @@
+package comphouse
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (m *testClock) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+func (m *testClock) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+}
+
+func createTestRateLimiter(limit int, window time.Duration) (*RateLimiter, *testClock) {
+	clock := &testClock{now: time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)}
+
+	l := NewRateLimiter(limit, window)
+	l.now = clock.Now
+
+	return l, clock
+}
+
+func TestRateLimiterConsumesAndRefillsTokens(t *testing.T) {
+	assert := assert.New(t)
+
+	l, clock := createTestRateLimiter(10, time.Second*10)
+
+	for i := 0; i < 10; i++ {
+		assert.Zero(l.reserve())
+	}
+
+	assert.Equal(0, l.Remaining())
+	assert.Equal(time.Second, l.reserve())
+
+	clock.Advance(time.Second * 3)
+
+	assert.Equal(3, l.Remaining())
+
+	clock.Advance(time.Hour)
+
+	assert.Equal(10, l.Remaining())
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	l, clock := createTestRateLimiter(DefaultRateLimit, DefaultRateLimitWindow)
+
+	l.Update(http.Header{
+		"X-Ratelimit-Limit":  {"1200"},
+		"X-Ratelimit-Window": {"10m"},
+		"X-Ratelimit-Remain": {"0"},
+		"X-Ratelimit-Reset":  {strconv.FormatInt(clock.Now().Add(time.Minute).Unix(), 10)},
+	})
+
+	assert.Equal(0, l.Remaining())
+	assert.Equal(time.Minute, l.reserve())
+
+	clock.Advance(time.Second * 30)
+
+	assert.Equal(0, l.Remaining())
+	assert.Equal(time.Second*30, l.reserve())
+
+	clock.Advance(time.Second * 30)
+
+	assert.Equal(1200, l.Remaining())
+	assert.Zero(l.reserve())
+}
+
+func TestRateLimiterUpdateIgnoresInvalidHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	l, _ := createTestRateLimiter(10, time.Minute)
+
+	l.Update(http.Header{
+		"X-Ratelimit-Limit":  {"lots"},
+		"X-Ratelimit-Remain": {"-1"},
+		"X-Ratelimit-Reset":  {"soon"},
+	})
+
+	assert.Equal(10, l.Remaining())
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewRateLimiter(1, time.Millisecond*20)
+
+	start := time.Now()
+
+	assert.NoError(l.Wait(context.Background()))
+	assert.NoError(l.Wait(context.Background()))
+
+	assert.GreaterOrEqual(int64(time.Since(start)), int64(time.Millisecond*15))
+}
+
+func TestRateLimiterWaitRespectsContext(t *testing.T) {
+	assert := assert.New(t)
+
+	l := NewRateLimiter(1, time.Hour)
+
+	assert.NoError(l.Wait(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+
+	assert.ErrorIs(l.Wait(ctx), context.DeadlineExceeded)
+}
+
+func TestRateLimiterIsSafeForConcurrentUse(t *testing.T) {
+	assert := assert.New(t)
+
+	l, _ := createTestRateLimiter(100, time.Hour)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(l.Wait(context.Background()))
+			l.Update(http.Header{})
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(50, l.Remaining())
+}
+
+func TestRateLimiterNil(t *testing.T) {
+	assert := assert.New(t)
+
+	var l *RateLimiter
+
+	assert.NoError(l.Wait(context.Background()))
+	assert.Greater(l.Remaining(), 0)
+
+	l.Update(http.Header{"X-Ratelimit-Remain": {"0"}})
+}
+
+func TestClientUpdatesRateLimiter(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Ratelimit-Limit", "600")
+		w.Header().Set("X-Ratelimit-Remain", "42")
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	_, err := c.Get("")
+
+	assert.NoError(err)
+	assert.Equal(42, c.Limiter.Remaining())
+}
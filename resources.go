@@ -1,5 +1,10 @@
 package comphouse
 
+import (
+	"encoding/json"
+	"sort"
+)
+
 // partially autogenerated using https://mholt.github.io/json-to-go/
 
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/companyprofile
@@ -24,15 +29,15 @@ type CompanyProfile struct {
 		Overdue      bool   `json:"overdue"`
 	} `json:"annual_return"`
 	BranchCompanyDetails struct {
-		BusinessActivity    string `json:"business_activity"`
-		ParentCompanyName   string `json:"parent_company_name"`
-		ParentCompanyNumber string `json:"parent_company_number"`
+		BusinessActivity    string    `json:"business_activity"`
+		ParentCompanyName   string    `json:"parent_company_name"`
+		ParentCompanyNumber CompanyNo `json:"parent_company_number"`
 	} `json:"branch_company_details"`
-	CanFile               bool   `json:"can_file"`
-	CompanyName           string `json:"company_name"`
-	CompanyNumber         string `json:"company_number"`
-	CompanyStatus         string `json:"company_status"`
-	CompanyStatusDetail   string `json:"company_status_detail"`
+	CanFile               bool      `json:"can_file"`
+	CompanyName           string    `json:"company_name"`
+	CompanyNumber         CompanyNo `json:"company_number"`
+	CompanyStatus         string    `json:"company_status"`
+	CompanyStatusDetail   string    `json:"company_status_detail"`
 	ConfirmationStatement struct {
 		LastMadeUpTo string `json:"last_made_up_to"`
 		NextDue      string `json:"next_due"`
@@ -87,88 +92,30 @@ type CompanyProfile struct {
 		EffectiveFrom string `json:"effective_from"`
 		Name          string `json:"name"`
 	} `json:"previous_company_names"`
-	RegisteredOfficeAddress struct {
-		AddressLine1 string `json:"address_line_1"`
-		AddressLine2 string `json:"address_line_2"`
-		CareOf       string `json:"care_of"`
-		Country      string `json:"country"`
-		Locality     string `json:"locality"`
-		PoBox        string `json:"po_box"`
-		PostalCode   string `json:"postal_code"`
-		Premises     string `json:"premises"`
-		Region       string `json:"region"`
-	} `json:"registered_office_address"`
-	RegisteredOfficeIsInDispute          bool   `json:"registered_office_is_in_dispute"`
-	SicCodes                             []SIC  `json:"sic_codes"`
-	Type                                 string `json:"type"`
-	UndeliverableRegisteredOfficeAddress bool   `json:"undeliverable_registered_office_address"`
+	RegisteredOfficeAddress              Address `json:"registered_office_address"`
+	RegisteredOfficeIsInDispute          bool    `json:"registered_office_is_in_dispute"`
+	SicCodes                             []SIC   `json:"sic_codes"`
+	Type                                 string  `json:"type"`
+	UndeliverableRegisteredOfficeAddress bool    `json:"undeliverable_registered_office_address"`
 }
 
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/registeredofficeaddress
 type RegisteredOfficeAddress struct {
-	AddressLine1 string `json:"address_line_1"`
-	AddressLine2 string `json:"address_line_2"`
-	Country      string `json:"country"`
-	Etag         string `json:"etag"`
-	Kind         string `json:"kind"`
-	Links        struct {
+	Address
+	Etag  string `json:"etag"`
+	Kind  string `json:"kind"`
+	Links struct {
 		Self string `json:"self"`
 	} `json:"links"`
-	Locality   string `json:"locality"`
-	PoBox      string `json:"po_box"`
-	PostalCode string `json:"postal_code"`
-	Premises   string `json:"premises"`
-	Region     string `json:"region"`
 }
 
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/officerlist
 type OfficerList struct {
-	ActiveCount int    `json:"active_count"`
-	Etag        string `json:"etag"`
-	Items       []struct {
-		Address struct {
-			AddressLine1 string `json:"address_line_1"`
-			AddressLine2 string `json:"address_line_2"`
-			CareOf       string `json:"care_of"`
-			Country      string `json:"country"`
-			Locality     string `json:"locality"`
-			PoBox        string `json:"po_box"`
-			PostalCode   string `json:"postal_code"`
-			Premises     string `json:"premises"`
-			Region       string `json:"region"`
-		} `json:"address"`
-		AppointedOn        string `json:"appointed_on"`
-		CountryOfResidence string `json:"country_of_residence"`
-		DateOfBirth        struct {
-			Day   int `json:"day"`
-			Month int `json:"month"`
-			Year  int `json:"year"`
-		} `json:"date_of_birth"`
-		FormerNames []struct {
-			Forenames string `json:"forenames"`
-			Surname   string `json:"surname"`
-		} `json:"former_names"`
-		Identification struct {
-			IdentificationType string `json:"identification_type"`
-			LegalAuthority     string `json:"legal_authority"`
-			LegalForm          string `json:"legal_form"`
-			PlaceRegistered    string `json:"place_registered"`
-			RegistrationNumber string `json:"registration_number"`
-		} `json:"identification"`
-		Links struct {
-			Officer struct {
-				Appointments string `json:"appointments"`
-			} `json:"officer"`
-			Self string `json:"self"`
-		} `json:"links"`
-		Name        string `json:"name"`
-		Nationality string `json:"nationality"`
-		Occupation  string `json:"occupation"`
-		OfficerRole string `json:"officer_role"`
-		ResignedOn  string `json:"resigned_on"`
-	} `json:"items"`
-	ItemsPerPage int    `json:"items_per_page"`
-	Kind         string `json:"kind"`
+	ActiveCount  int               `json:"active_count"`
+	Etag         string            `json:"etag"`
+	Items        []OfficerListItem `json:"items"`
+	ItemsPerPage int               `json:"items_per_page"`
+	Kind         string            `json:"kind"`
 	Links        struct {
 		Self string `json:"self"`
 	} `json:"links"`
@@ -177,21 +124,45 @@ type OfficerList struct {
 	TotalResults  int `json:"total_results"`
 }
 
+// OfficerListItem is a single officer appointment in an OfficerList
+type OfficerListItem struct {
+	Address            Address `json:"address"`
+	AppointedOn        string  `json:"appointed_on"`
+	CountryOfResidence string  `json:"country_of_residence"`
+	DateOfBirth        struct {
+		Day   int `json:"day"`
+		Month int `json:"month"`
+		Year  int `json:"year"`
+	} `json:"date_of_birth"`
+	FormerNames []struct {
+		Forenames string `json:"forenames"`
+		Surname   string `json:"surname"`
+	} `json:"former_names"`
+	Identification struct {
+		IdentificationType string `json:"identification_type"`
+		LegalAuthority     string `json:"legal_authority"`
+		LegalForm          string `json:"legal_form"`
+		PlaceRegistered    string `json:"place_registered"`
+		RegistrationNumber string `json:"registration_number"`
+	} `json:"identification"`
+	Links struct {
+		Officer struct {
+			Appointments string `json:"appointments"`
+		} `json:"officer"`
+		Self string `json:"self"`
+	} `json:"links"`
+	Name        string `json:"name"`
+	Nationality string `json:"nationality"`
+	Occupation  string `json:"occupation"`
+	OfficerRole string `json:"officer_role"`
+	ResignedOn  string `json:"resigned_on"`
+}
+
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/officersummary
 type OfficerSummary struct {
-	Address struct {
-		AddressLine1 string `json:"address_line_1"`
-		AddressLine2 string `json:"address_line_2"`
-		CareOf       string `json:"care_of"`
-		Country      string `json:"country"`
-		Locality     string `json:"locality"`
-		PoBox        string `json:"po_box"`
-		PostalCode   string `json:"postal_code"`
-		Premises     string `json:"premises"`
-		Region       string `json:"region"`
-	} `json:"address"`
-	AppointedOn        string `json:"appointed_on"`
-	CountryOfResidence string `json:"country_of_residence"`
+	Address            Address `json:"address"`
+	AppointedOn        string  `json:"appointed_on"`
+	CountryOfResidence string  `json:"country_of_residence"`
 	DateOfBirth        struct {
 		Day   int `json:"day"`
 		Month int `json:"month"`
@@ -223,9 +194,9 @@ type OfficerSummary struct {
 
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/companyregister
 type CompanyRegister struct {
-	CompanyNumber string `json:"company_number"`
-	Etag          string `json:"etag"`
-	Kind          string `json:"kind"`
+	CompanyNumber CompanyNo `json:"company_number"`
+	Etag          string    `json:"etag"`
+	Kind          string    `json:"kind"`
 	Links         struct {
 		Self string `json:"self"`
 	} `json:"links"`
@@ -326,73 +297,76 @@ type CompanyRegister struct {
 
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/chargelist
 type ChargeList struct {
-	Etag  string `json:"etag"`
-	Items []struct {
-		AcquiredOn            string `json:"acquired_on"`
-		AssestsCeasedReleased string `json:"assests_ceased_released"`
-		ChargeCode            string `json:"charge_code"`
-		ChargeNumber          int    `json:"charge_number"`
-		Classification        struct {
-			Description string `json:"description"`
-			Type        string `json:"type"`
-		} `json:"classification"`
-		CoveringInstrumentDate string `json:"covering_instrument_date"`
-		CreatedOn              string `json:"created_on"`
-		DeliveredOn            string `json:"delivered_on"`
-		Etag                   string `json:"etag"`
-		ID                     string `json:"id"`
-		InsolvencyCases        []struct {
-			CaseNumber int `json:"case_number"`
-			Links      []struct {
-				Case string `json:"case"`
-			} `json:"links"`
-			TransactionID int `json:"transaction_id"`
-		} `json:"insolvency_cases"`
-		Links struct {
-			Self string `json:"self"`
+	Etag               string           `json:"etag"`
+	Items              []ChargeListItem `json:"items"`
+	PartSatisfiedCount int              `json:"part_satisfied_count"`
+	SatisfiedCount     int              `json:"satisfied_count"`
+	TotalCount         int              `json:"total_count"`
+	UnfilteredCount    int              `json:"unfiltered_count"`
+}
+
+// ChargeListItem is a single charge in a ChargeList
+type ChargeListItem struct {
+	AcquiredOn            string `json:"acquired_on"`
+	AssestsCeasedReleased string `json:"assests_ceased_released"`
+	ChargeCode            string `json:"charge_code"`
+	ChargeNumber          int    `json:"charge_number"`
+	Classification        struct {
+		Description string `json:"description"`
+		Type        string `json:"type"`
+	} `json:"classification"`
+	CoveringInstrumentDate string `json:"covering_instrument_date"`
+	CreatedOn              string `json:"created_on"`
+	DeliveredOn            string `json:"delivered_on"`
+	Etag                   string `json:"etag"`
+	ID                     string `json:"id"`
+	InsolvencyCases        []struct {
+		CaseNumber int `json:"case_number"`
+		Links      []struct {
+			Case string `json:"case"`
 		} `json:"links"`
-		MoreThanFourPersonsEntitled bool `json:"more_than_four_persons_entitled"`
-		Particulars                 struct {
-			ChargorActingAsBareTrustee bool   `json:"chargor_acting_as_bare_trustee"`
-			ContainsFixedCharge        bool   `json:"contains_fixed_charge"`
-			ContainsFloatingCharge     bool   `json:"contains_floating_charge"`
-			ContainsNegativePledge     bool   `json:"contains_negative_pledge"`
-			Description                string `json:"description"`
-			FloatingChargeCoversAll    bool   `json:"floating_charge_covers_all"`
-			Type                       string `json:"type"`
-		} `json:"particulars"`
-		PersonsEntitled []struct {
-			Name string `json:"name"`
-		} `json:"persons_entitled"`
-		ResolvedOn          string `json:"resolved_on"`
-		SatisfiedOn         string `json:"satisfied_on"`
-		ScottishAlterations []struct {
-			Description                  string `json:"description"`
-			HasAlterationsToOrder        bool   `json:"has_alterations_to_order"`
-			HasAlterationsToProhibitions bool   `json:"has_alterations_to_prohibitions"`
-			HasAlterationsToProvisions   bool   `json:"has_alterations_to_provisions"`
-			Type                         string `json:"type"`
-		} `json:"scottish_alterations"`
-		SecuredDetails struct {
-			Description string `json:"description"`
-			Type        string `json:"type"`
-		} `json:"secured_details"`
-		Status       string `json:"status"`
-		Transactions []struct {
-			DeliveredOn          string `json:"delivered_on"`
-			FilingType           string `json:"filing_type"`
-			InsolvencyCaseNumber int    `json:"insolvency_case_number"`
-			Links                struct {
-				Filing         string `json:"filing"`
-				InsolvencyCase string `json:"insolvency_case"`
-			} `json:"links"`
-			TransactionID int `json:"transaction_id"`
-		} `json:"transactions"`
-	} `json:"items"`
-	PartSatisfiedCount int `json:"part_satisfied_count"`
-	SatisfiedCount     int `json:"satisfied_count"`
-	TotalCount         int `json:"total_count"`
-	UnfilteredCount    int `json:"unfiltered_count"`
+		TransactionID int `json:"transaction_id"`
+	} `json:"insolvency_cases"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	MoreThanFourPersonsEntitled bool `json:"more_than_four_persons_entitled"`
+	Particulars                 struct {
+		ChargorActingAsBareTrustee bool   `json:"chargor_acting_as_bare_trustee"`
+		ContainsFixedCharge        bool   `json:"contains_fixed_charge"`
+		ContainsFloatingCharge     bool   `json:"contains_floating_charge"`
+		ContainsNegativePledge     bool   `json:"contains_negative_pledge"`
+		Description                string `json:"description"`
+		FloatingChargeCoversAll    bool   `json:"floating_charge_covers_all"`
+		Type                       string `json:"type"`
+	} `json:"particulars"`
+	PersonsEntitled []struct {
+		Name string `json:"name"`
+	} `json:"persons_entitled"`
+	ResolvedOn          string `json:"resolved_on"`
+	SatisfiedOn         string `json:"satisfied_on"`
+	ScottishAlterations []struct {
+		Description                  string `json:"description"`
+		HasAlterationsToOrder        bool   `json:"has_alterations_to_order"`
+		HasAlterationsToProhibitions bool   `json:"has_alterations_to_prohibitions"`
+		HasAlterationsToProvisions   bool   `json:"has_alterations_to_provisions"`
+		Type                         string `json:"type"`
+	} `json:"scottish_alterations"`
+	SecuredDetails struct {
+		Description string `json:"description"`
+		Type        string `json:"type"`
+	} `json:"secured_details"`
+	Status       string `json:"status"`
+	Transactions []struct {
+		DeliveredOn          string `json:"delivered_on"`
+		FilingType           string `json:"filing_type"`
+		InsolvencyCaseNumber int    `json:"insolvency_case_number"`
+		Links                struct {
+			Filing         string `json:"filing"`
+			InsolvencyCase string `json:"insolvency_case"`
+		} `json:"links"`
+		TransactionID int `json:"transaction_id"`
+	} `json:"transactions"`
 }
 
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/chargedetails
@@ -459,153 +433,822 @@ type ChargeDetails struct {
 	} `json:"transactions"`
 }
 
-// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/search
-type Search struct {
-	Etag  string `json:"etag"`
-	Items []struct {
-		Address struct {
-			AddressLine1 string `json:"address_line_1"`
-			AddressLine2 string `json:"address_line_2"`
-			CareOf       string `json:"care_of"`
-			Country      string `json:"country"`
-			Locality     string `json:"locality"`
-			PoBox        string `json:"po_box"`
-			PostalCode   string `json:"postal_code"`
-			Region       string `json:"region"`
-		} `json:"address"`
-		AddressSnippet        string   `json:"address_snippet"`
-		Description           string   `json:"description"`
-		DescriptionIdentifier []string `json:"description_identifier"`
-		Kind                  string   `json:"kind"`
-		Links                 struct {
-			Self string `json:"self"`
-		} `json:"links"`
-		Matches struct {
-			AddressSnippet []int `json:"address_snippet"`
-			Snippet        []int `json:"snippet"`
-			Title          []int `json:"title"`
-		} `json:"matches"`
-		Snippet string `json:"snippet"`
-		Title   string `json:"title"`
-	} `json:"items"`
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/filinghistorylist
+type FilingHistoryList struct {
+	Etag                string              `json:"etag"`
+	FilingHistoryStatus string              `json:"filing_history_status"`
+	Items               []FilingHistoryItem `json:"items"`
+	ItemsPerPage        int                 `json:"items_per_page"`
+	Kind                string              `json:"kind"`
+	StartIndex          int                 `json:"start_index"`
+	TotalCount          int                 `json:"total_count"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/filinghistoryitem
+type FilingHistoryItem struct {
+	Annotations []struct {
+		Annotation  string `json:"annotation"`
+		Date        string `json:"date"`
+		Description string `json:"description"`
+	} `json:"annotations"`
+	AssociatedFilings []struct {
+		Date              string                 `json:"date"`
+		Description       string                 `json:"description"`
+		DescriptionValues map[string]interface{} `json:"description_values"`
+		Type              string                 `json:"type"`
+	} `json:"associated_filings"`
+	Barcode           string                 `json:"barcode"`
+	Category          string                 `json:"category"`
+	Date              string                 `json:"date"`
+	Description       string                 `json:"description"`
+	DescriptionValues map[string]interface{} `json:"description_values"`
+	Links             struct {
+		DocumentMetadata string `json:"document_metadata"`
+		Self             string `json:"self"`
+	} `json:"links"`
+	Pages       int  `json:"pages"`
+	PaperFiled  bool `json:"paper_filed"`
+	Resolutions []struct {
+		Category          string                 `json:"category"`
+		Description       string                 `json:"description"`
+		DescriptionValues map[string]interface{} `json:"description_values"`
+		DocumentID        string                 `json:"document_id"`
+		ReceiveDate       string                 `json:"receive_date"`
+		Subcategory       string                 `json:"subcategory"`
+		Type              string                 `json:"type"`
+	} `json:"resolutions"`
+	Subcategory   string `json:"subcategory"`
+	TransactionID string `json:"transaction_id"`
+	Type          string `json:"type"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/list
+type PSCList struct {
+	ActiveCount  int    `json:"active_count"`
+	CeasedCount  int    `json:"ceased_count"`
+	Etag         string `json:"etag"`
+	Items        []PSC  `json:"items"`
 	ItemsPerPage int    `json:"items_per_page"`
 	Kind         string `json:"kind"`
-	StartIndex   int    `json:"start_index"`
-	TotalResults int    `json:"total_results"`
+	Links        struct {
+		PersonsWithSignificantControlStatements string `json:"persons_with_significant_control_statements"`
+		Self                                    string `json:"self"`
+	} `json:"links"`
+	StartIndex   int `json:"start_index"`
+	TotalResults int `json:"total_results"`
 }
 
-// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/companysearch
-type CompanySearch struct {
+// PSC is an item in a PSCList. The Kind field determines which type of person
+// with significant control the item describes and which fields are populated
+type PSC struct {
+	Address            Address `json:"address"`
+	Ceased             bool    `json:"ceased"`
+	CeasedOn           string  `json:"ceased_on"`
+	CountryOfResidence string  `json:"country_of_residence"`
+	DateOfBirth        struct {
+		Month int `json:"month"`
+		Year  int `json:"year"`
+	} `json:"date_of_birth"`
+	Description    string `json:"description"`
+	Etag           string `json:"etag"`
+	Identification struct {
+		CountryRegistered  string `json:"country_registered"`
+		LegalAuthority     string `json:"legal_authority"`
+		LegalForm          string `json:"legal_form"`
+		PlaceRegistered    string `json:"place_registered"`
+		RegistrationNumber string `json:"registration_number"`
+	} `json:"identification"`
+	IsSanctioned bool   `json:"is_sanctioned"`
+	Kind         string `json:"kind"`
+	Links        struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	} `json:"links"`
+	Name         string `json:"name"`
+	NameElements struct {
+		Forename       string `json:"forename"`
+		OtherForenames string `json:"other_forenames"`
+		Surname        string `json:"surname"`
+		Title          string `json:"title"`
+	} `json:"name_elements"`
+	Nationality            string   `json:"nationality"`
+	NaturesOfControl       []string `json:"natures_of_control"`
+	NotifiedOn             string   `json:"notified_on"`
+	PrincipalOfficeAddress Address  `json:"principal_office_address"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/individual
+type PSCIndividual struct {
+	Address            Address `json:"address"`
+	CeasedOn           string  `json:"ceased_on"`
+	CountryOfResidence string  `json:"country_of_residence"`
+	DateOfBirth        struct {
+		Month int `json:"month"`
+		Year  int `json:"year"`
+	} `json:"date_of_birth"`
 	Etag  string `json:"etag"`
-	Items []struct {
-		Address struct {
-			AddressLine1 string `json:"address_line_1"`
-			AddressLine2 string `json:"address_line_2"`
-			CareOf       string `json:"care_of"`
-			Country      string `json:"country"`
-			Locality     string `json:"locality"`
-			PoBox        string `json:"po_box"`
-			PostalCode   string `json:"postal_code"`
-			Region       string `json:"region"`
-		} `json:"address"`
-		AddressSnippet        string   `json:"address_snippet"`
-		CompanyNumber         string   `json:"company_number"`
-		CompanyStatus         string   `json:"company_status"`
-		CompanyType           string   `json:"company_type"`
-		DateOfCessation       string   `json:"date_of_cessation"`
-		DateOfCreation        string   `json:"date_of_creation"`
-		Description           string   `json:"description"`
-		DescriptionIdentifier []string `json:"description_identifier"`
-		Kind                  string   `json:"kind"`
-		Links                 struct {
-			Self string `json:"self"`
+	Kind  string `json:"kind"`
+	Links struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	} `json:"links"`
+	Name         string `json:"name"`
+	NameElements struct {
+		Forename       string `json:"forename"`
+		OtherForenames string `json:"other_forenames"`
+		Surname        string `json:"surname"`
+		Title          string `json:"title"`
+	} `json:"name_elements"`
+	Nationality      string   `json:"nationality"`
+	NaturesOfControl []string `json:"natures_of_control"`
+	NotifiedOn       string   `json:"notified_on"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/individualbeneficialowner
+type PSCIndividualBeneficialOwner struct {
+	Address     Address `json:"address"`
+	CeasedOn    string  `json:"ceased_on"`
+	DateOfBirth struct {
+		Month int `json:"month"`
+		Year  int `json:"year"`
+	} `json:"date_of_birth"`
+	Etag         string `json:"etag"`
+	IsSanctioned bool   `json:"is_sanctioned"`
+	Kind         string `json:"kind"`
+	Links        struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	} `json:"links"`
+	Name         string `json:"name"`
+	NameElements struct {
+		Forename       string `json:"forename"`
+		OtherForenames string `json:"other_forenames"`
+		Surname        string `json:"surname"`
+		Title          string `json:"title"`
+	} `json:"name_elements"`
+	Nationality      string   `json:"nationality"`
+	NaturesOfControl []string `json:"natures_of_control"`
+	NotifiedOn       string   `json:"notified_on"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/corporateentity
+type PSCCorporateEntity struct {
+	Address        Address `json:"address"`
+	CeasedOn       string  `json:"ceased_on"`
+	Etag           string  `json:"etag"`
+	Identification struct {
+		CountryRegistered  string `json:"country_registered"`
+		LegalAuthority     string `json:"legal_authority"`
+		LegalForm          string `json:"legal_form"`
+		PlaceRegistered    string `json:"place_registered"`
+		RegistrationNumber string `json:"registration_number"`
+	} `json:"identification"`
+	Kind  string `json:"kind"`
+	Links struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	} `json:"links"`
+	Name             string   `json:"name"`
+	NaturesOfControl []string `json:"natures_of_control"`
+	NotifiedOn       string   `json:"notified_on"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/corporateentitybeneficialowner
+type PSCCorporateEntityBeneficialOwner struct {
+	Address        Address `json:"address"`
+	CeasedOn       string  `json:"ceased_on"`
+	Etag           string  `json:"etag"`
+	Identification struct {
+		CountryRegistered  string `json:"country_registered"`
+		LegalAuthority     string `json:"legal_authority"`
+		LegalForm          string `json:"legal_form"`
+		PlaceRegistered    string `json:"place_registered"`
+		RegistrationNumber string `json:"registration_number"`
+	} `json:"identification"`
+	IsSanctioned bool   `json:"is_sanctioned"`
+	Kind         string `json:"kind"`
+	Links        struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	} `json:"links"`
+	Name                   string   `json:"name"`
+	NaturesOfControl       []string `json:"natures_of_control"`
+	NotifiedOn             string   `json:"notified_on"`
+	PrincipalOfficeAddress Address  `json:"principal_office_address"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/legalperson
+type PSCLegalPerson struct {
+	Address        Address `json:"address"`
+	CeasedOn       string  `json:"ceased_on"`
+	Etag           string  `json:"etag"`
+	Identification struct {
+		LegalAuthority string `json:"legal_authority"`
+		LegalForm      string `json:"legal_form"`
+	} `json:"identification"`
+	Kind  string `json:"kind"`
+	Links struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	} `json:"links"`
+	Name             string   `json:"name"`
+	NaturesOfControl []string `json:"natures_of_control"`
+	NotifiedOn       string   `json:"notified_on"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/legalpersonbeneficialowner
+type PSCLegalPersonBeneficialOwner struct {
+	Address        Address `json:"address"`
+	CeasedOn       string  `json:"ceased_on"`
+	Etag           string  `json:"etag"`
+	Identification struct {
+		LegalAuthority string `json:"legal_authority"`
+		LegalForm      string `json:"legal_form"`
+	} `json:"identification"`
+	IsSanctioned bool   `json:"is_sanctioned"`
+	Kind         string `json:"kind"`
+	Links        struct {
+		Self      string `json:"self"`
+		Statement string `json:"statement"`
+	} `json:"links"`
+	Name                   string   `json:"name"`
+	NaturesOfControl       []string `json:"natures_of_control"`
+	NotifiedOn             string   `json:"notified_on"`
+	PrincipalOfficeAddress Address  `json:"principal_office_address"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/supersecure
+type PSCSuperSecure struct {
+	Ceased      bool   `json:"ceased"`
+	Description string `json:"description"`
+	Etag        string `json:"etag"`
+	Kind        string `json:"kind"`
+	Links       struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/supersecurebeneficialowner
+type PSCSuperSecureBeneficialOwner struct {
+	Ceased      bool   `json:"ceased"`
+	Description string `json:"description"`
+	Etag        string `json:"etag"`
+	Kind        string `json:"kind"`
+	Links       struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/statementlist
+type PSCStatementList struct {
+	ActiveCount  int            `json:"active_count"`
+	CeasedCount  int            `json:"ceased_count"`
+	Etag         string         `json:"etag"`
+	Items        []PSCStatement `json:"items"`
+	ItemsPerPage int            `json:"items_per_page"`
+	Kind         string         `json:"kind"`
+	Links        struct {
+		PersonsWithSignificantControl string `json:"persons_with_significant_control"`
+		Self                          string `json:"self"`
+	} `json:"links"`
+	StartIndex   int `json:"start_index"`
+	TotalResults int `json:"total_results"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/statement
+type PSCStatement struct {
+	CeasedOn      string `json:"ceased_on"`
+	Etag          string `json:"etag"`
+	Kind          string `json:"kind"`
+	LinkedPscName string `json:"linked_psc_name"`
+	Links         struct {
+		PersonWithSignificantControl string `json:"person_with_significant_control"`
+		Self                         string `json:"self"`
+	} `json:"links"`
+	NotifiedOn                         string `json:"notified_on"`
+	RestrictionsNoticeWithdrawalReason string `json:"restrictions_notice_withdrawal_reason"`
+	Statement                          string `json:"statement"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/companyinsolvency
+type CompanyInsolvency struct {
+	Cases []struct {
+		Dates []struct {
+			Date string `json:"date"`
+			Type string `json:"type"`
+		} `json:"dates"`
+		Links struct {
+			Charge string `json:"charge"`
 		} `json:"links"`
-		Matches struct {
-			AddressSnippet []int `json:"address_snippet"`
-			Snippet        []int `json:"snippet"`
-			Title          []int `json:"title"`
-		} `json:"matches"`
-		Snippet string `json:"snippet"`
-		Title   string `json:"title"`
+		Notes         []string `json:"notes"`
+		Number        string   `json:"number"`
+		Practitioners []struct {
+			Address       Address `json:"address"`
+			AppointedOn   string  `json:"appointed_on"`
+			CeasedToActOn string  `json:"ceased_to_act_on"`
+			Name          string  `json:"name"`
+			Role          string  `json:"role"`
+		} `json:"practitioners"`
+		Type string `json:"type"`
+	} `json:"cases"`
+	Etag   string   `json:"etag"`
+	Status []string `json:"status"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/companyexemptions
+type CompanyExemptions struct {
+	Etag       string `json:"etag"`
+	Exemptions struct {
+		DisclosureTransparencyRulesChapterFiveApplies CompanyExemption `json:"disclosure_transparency_rules_chapter_five_applies"`
+		PscExemptAsSharesAdmittedOnMarket             CompanyExemption `json:"psc_exempt_as_shares_admitted_on_market"`
+		PscExemptAsTradingOnEuRegulatedMarket         CompanyExemption `json:"psc_exempt_as_trading_on_eu_regulated_market"`
+		PscExemptAsTradingOnRegulatedMarket           CompanyExemption `json:"psc_exempt_as_trading_on_regulated_market"`
+		PscExemptAsTradingOnUkRegulatedMarket         CompanyExemption `json:"psc_exempt_as_trading_on_uk_regulated_market"`
+	} `json:"exemptions"`
+	Kind  string `json:"kind"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"links"`
+}
+
+// CompanyExemption describes the periods during which a company was exempt
+// from a particular requirement
+type CompanyExemption struct {
+	ExemptionType string `json:"exemption_type"`
+	Items         []struct {
+		ExemptFrom string `json:"exempt_from"`
+		ExemptTo   string `json:"exempt_to"`
 	} `json:"items"`
-	ItemsPerPage int    `json:"items_per_page"`
-	Kind         string `json:"kind"`
-	StartIndex   int    `json:"start_index"`
-	TotalResults int    `json:"total_results"`
 }
 
-// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/officersearch
-type OfficerSearch struct {
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/companyukestablishments
+type CompanyUKEstablishments struct {
 	Etag  string `json:"etag"`
 	Items []struct {
-		Address struct {
-			AddressLine1 string `json:"address_line_1"`
-			AddressLine2 string `json:"address_line_2"`
-			CareOf       string `json:"care_of"`
-			Country      string `json:"country"`
-			Locality     string `json:"locality"`
-			PoBox        string `json:"po_box"`
-			PostalCode   string `json:"postal_code"`
-			Premises     string `json:"premises"`
-			Region       string `json:"region"`
-		} `json:"address"`
-		AddressSnippet   string `json:"address_snippet"`
-		AppointmentCount int    `json:"appointment_count"`
-		DateOfBirth      struct {
-			Month int `json:"month"`
-			Year  int `json:"year"`
-		} `json:"date_of_birth"`
-		Description            string   `json:"description"`
-		DescriptionIdentifiers []string `json:"description_identifiers"`
-		Kind                   string   `json:"kind"`
-		Links                  struct {
-			Self string `json:"self"`
+		CompanyName string `json:"company_name"`
+		// CompanyNumber is the "BR" number of a UK establishment rather
+		// than a company number so is left as a string
+		CompanyNumber string `json:"company_number"`
+		CompanyStatus string `json:"company_status"`
+		Links         struct {
+			Company string `json:"company"`
 		} `json:"links"`
-		Matches struct {
-			AddressSnippet []int `json:"address_snippet"`
-			Snippet        []int `json:"snippet"`
-			Title          []int `json:"title"`
-		} `json:"matches"`
-		Snippet string `json:"snippet"`
-		Title   string `json:"title"`
+		Locality string `json:"locality"`
 	} `json:"items"`
-	ItemsPerPage int    `json:"items_per_page"`
-	Kind         string `json:"kind"`
-	StartIndex   int    `json:"start_index"`
-	TotalResults int    `json:"total_results"`
+	Kind  string `json:"kind"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"links"`
 }
 
-// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/disqualifiedofficersearch
-type DisqualifiedOfficerSearch struct {
-	Etag  string `json:"etag"`
-	Items []struct {
-		Address struct {
-			AddressLine1 string `json:"address_line_1"`
-			AddressLine2 string `json:"address_line_2"`
-			Country      string `json:"country"`
-			Locality     string `json:"locality"`
-			PostalCode   string `json:"postal_code"`
-			Premises     string `json:"premises"`
-			Region       string `json:"region"`
-		} `json:"address"`
-		AddressSnippet         string   `json:"address_snippet"`
-		DateOfBirth            string   `json:"date_of_birth"`
-		Description            string   `json:"description"`
-		DescriptionIdentifiers []string `json:"description_identifiers"`
-		Kind                   string   `json:"kind"`
-		Links                  struct {
-			Self string `json:"self"`
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/appointmentlist
+type AppointmentList struct {
+	DateOfBirth struct {
+		Month int `json:"month"`
+		Year  int `json:"year"`
+	} `json:"date_of_birth"`
+	Etag               string `json:"etag"`
+	IsCorporateOfficer bool   `json:"is_corporate_officer"`
+	Items              []struct {
+		Address         Address `json:"address"`
+		AppointedBefore string  `json:"appointed_before"`
+		AppointedOn     string  `json:"appointed_on"`
+		AppointedTo     struct {
+			CompanyName   string    `json:"company_name"`
+			CompanyNumber CompanyNo `json:"company_number"`
+			CompanyStatus string    `json:"company_status"`
+		} `json:"appointed_to"`
+		CountryOfResidence string `json:"country_of_residence"`
+		FormerNames        []struct {
+			Forenames string `json:"forenames"`
+			Surname   string `json:"surname"`
+		} `json:"former_names"`
+		Identification struct {
+			IdentificationType string `json:"identification_type"`
+			LegalAuthority     string `json:"legal_authority"`
+			LegalForm          string `json:"legal_form"`
+			PlaceRegistered    string `json:"place_registered"`
+			RegistrationNumber string `json:"registration_number"`
+		} `json:"identification"`
+		IsPre1992Appointment bool `json:"is_pre_1992_appointment"`
+		Links                struct {
+			Company string `json:"company"`
 		} `json:"links"`
-		Matches struct {
-			AddressSnippet []int `json:"address_snippet"`
-			Snippet        []int `json:"snippet"`
-			Title          []int `json:"title"`
-		} `json:"matches"`
-		Snippet string `json:"snippet"`
-		Title   string `json:"title"`
+		Name         string `json:"name"`
+		NameElements struct {
+			Forename       string `json:"forename"`
+			Honours        string `json:"honours"`
+			OtherForenames string `json:"other_forenames"`
+			Surname        string `json:"surname"`
+			Title          string `json:"title"`
+		} `json:"name_elements"`
+		Nationality string `json:"nationality"`
+		Occupation  string `json:"occupation"`
+		OfficerRole string `json:"officer_role"`
+		ResignedOn  string `json:"resigned_on"`
 	} `json:"items"`
 	ItemsPerPage int    `json:"items_per_page"`
 	Kind         string `json:"kind"`
+	Links        struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	Name         string `json:"name"`
 	StartIndex   int    `json:"start_index"`
 	TotalResults int    `json:"total_results"`
 }
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/naturaldisqualification
+type NaturalDisqualifiedOfficer struct {
+	DateOfBirth       string             `json:"date_of_birth"`
+	Disqualifications []Disqualification `json:"disqualifications"`
+	Etag              string             `json:"etag"`
+	Forename          string             `json:"forename"`
+	Honours           string             `json:"honours"`
+	Kind              string             `json:"kind"`
+	Links             struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	Nationality      string            `json:"nationality"`
+	OtherForenames   string            `json:"other_forenames"`
+	PermissionsToAct []PermissionToAct `json:"permissions_to_act"`
+	Surname          string            `json:"surname"`
+	Title            string            `json:"title"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/corporatedisqualification
+type CorporateDisqualifiedOfficer struct {
+	CompanyNumber         CompanyNo          `json:"company_number"`
+	CountryOfRegistration string             `json:"country_of_registration"`
+	Disqualifications     []Disqualification `json:"disqualifications"`
+	Etag                  string             `json:"etag"`
+	Kind                  string             `json:"kind"`
+	Links                 struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	Name             string            `json:"name"`
+	PermissionsToAct []PermissionToAct `json:"permissions_to_act"`
+}
+
+// Disqualification is a single disqualification order or undertaking held
+// against an officer
+type Disqualification struct {
+	Address              Address  `json:"address"`
+	CaseIdentifier       string   `json:"case_identifier"`
+	CompanyNames         []string `json:"company_names"`
+	CourtName            string   `json:"court_name"`
+	DisqualificationType string   `json:"disqualification_type"`
+	DisqualifiedFrom     string   `json:"disqualified_from"`
+	DisqualifiedUntil    string   `json:"disqualified_until"`
+	HeardOn              string   `json:"heard_on"`
+	LastVariation        []struct {
+		CaseIdentifier string `json:"case_identifier"`
+		CourtName      string `json:"court_name"`
+		VariedOn       string `json:"varied_on"`
+	} `json:"last_variation"`
+	Reason struct {
+		Act                   string `json:"act"`
+		Article               string `json:"article"`
+		DescriptionIdentifier string `json:"description_identifier"`
+		Section               string `json:"section"`
+	} `json:"reason"`
+	UndertakenOn string `json:"undertaken_on"`
+}
+
+// PermissionToAct is a court's permission for a disqualified officer to act
+// for the named companies
+type PermissionToAct struct {
+	CompanyNames []string `json:"company_names"`
+	CourtName    string   `json:"court_name"`
+	ExpiresOn    string   `json:"expires_on"`
+	GrantedOn    string   `json:"granted_on"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/search
+type Search struct {
+	Etag         string       `json:"etag"`
+	Items        []SearchItem `json:"items"`
+	ItemsPerPage int          `json:"items_per_page"`
+	Kind         string       `json:"kind"`
+	StartIndex   int          `json:"start_index"`
+	TotalResults int          `json:"total_results"`
+}
+
+// SearchItem is a single company, officer or disqualified officer matched by
+// a search
+type SearchItem struct {
+	Address               Address  `json:"address"`
+	AddressSnippet        string   `json:"address_snippet"`
+	Description           string   `json:"description"`
+	DescriptionIdentifier []string `json:"description_identifier"`
+	Kind                  string   `json:"kind"`
+	Links                 struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	Matches struct {
+		AddressSnippet []int `json:"address_snippet"`
+		Snippet        []int `json:"snippet"`
+		Title          []int `json:"title"`
+	} `json:"matches"`
+	Snippet string `json:"snippet"`
+	Title   string `json:"title"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/companysearch
+type CompanySearch struct {
+	Etag         string              `json:"etag"`
+	Items        []CompanySearchItem `json:"items"`
+	ItemsPerPage int                 `json:"items_per_page"`
+	Kind         string              `json:"kind"`
+	StartIndex   int                 `json:"start_index"`
+	TotalResults int                 `json:"total_results"`
+}
+
+// CompanySearchItem is a single company matched by a company search
+type CompanySearchItem struct {
+	Address               Address   `json:"address"`
+	AddressSnippet        string    `json:"address_snippet"`
+	CompanyNumber         CompanyNo `json:"company_number"`
+	CompanyStatus         string    `json:"company_status"`
+	CompanyType           string    `json:"company_type"`
+	DateOfCessation       string    `json:"date_of_cessation"`
+	DateOfCreation        string    `json:"date_of_creation"`
+	Description           string    `json:"description"`
+	DescriptionIdentifier []string  `json:"description_identifier"`
+	Kind                  string    `json:"kind"`
+	Links                 struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	Matches struct {
+		AddressSnippet []int `json:"address_snippet"`
+		Snippet        []int `json:"snippet"`
+		Title          []int `json:"title"`
+	} `json:"matches"`
+	Snippet string `json:"snippet"`
+	Title   string `json:"title"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/officersearch
+type OfficerSearch struct {
+	Etag         string              `json:"etag"`
+	Items        []OfficerSearchItem `json:"items"`
+	ItemsPerPage int                 `json:"items_per_page"`
+	Kind         string              `json:"kind"`
+	StartIndex   int                 `json:"start_index"`
+	TotalResults int                 `json:"total_results"`
+}
+
+// OfficerSearchItem is a single officer matched by an officer search
+type OfficerSearchItem struct {
+	Address          Address `json:"address"`
+	AddressSnippet   string  `json:"address_snippet"`
+	AppointmentCount int     `json:"appointment_count"`
+	DateOfBirth      struct {
+		Month int `json:"month"`
+		Year  int `json:"year"`
+	} `json:"date_of_birth"`
+	Description            string   `json:"description"`
+	DescriptionIdentifiers []string `json:"description_identifiers"`
+	Kind                   string   `json:"kind"`
+	Links                  struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	Matches struct {
+		AddressSnippet []int `json:"address_snippet"`
+		Snippet        []int `json:"snippet"`
+		Title          []int `json:"title"`
+	} `json:"matches"`
+	Snippet string `json:"snippet"`
+	Title   string `json:"title"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/disqualifiedofficersearch
+type DisqualifiedOfficerSearch struct {
+	Etag         string                          `json:"etag"`
+	Items        []DisqualifiedOfficerSearchItem `json:"items"`
+	ItemsPerPage int                             `json:"items_per_page"`
+	Kind         string                          `json:"kind"`
+	StartIndex   int                             `json:"start_index"`
+	TotalResults int                             `json:"total_results"`
+}
+
+// DisqualifiedOfficerSearchItem is a single disqualified officer matched by a
+// disqualified officer search
+type DisqualifiedOfficerSearchItem struct {
+	Address                Address  `json:"address"`
+	AddressSnippet         string   `json:"address_snippet"`
+	DateOfBirth            string   `json:"date_of_birth"`
+	Description            string   `json:"description"`
+	DescriptionIdentifiers []string `json:"description_identifiers"`
+	Kind                   string   `json:"kind"`
+	Links                  struct {
+		Self string `json:"self"`
+	} `json:"links"`
+	Matches struct {
+		AddressSnippet []int `json:"address_snippet"`
+		Snippet        []int `json:"snippet"`
+		Title          []int `json:"title"`
+	} `json:"matches"`
+	Snippet string `json:"snippet"`
+	Title   string `json:"title"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/advancedcompanysearch
+type AdvancedCompanySearch struct {
+	Etag   string                      `json:"etag"`
+	Hits   int                         `json:"hits"`
+	Items  []AdvancedCompanySearchItem `json:"items"`
+	Kind   string                      `json:"kind"`
+	TopHit AdvancedCompanySearchItem   `json:"top_hit"`
+}
+
+// AdvancedCompanySearchItem is a single company matched by an advanced search
+type AdvancedCompanySearchItem struct {
+	CompanyName     string    `json:"company_name"`
+	CompanyNumber   CompanyNo `json:"company_number"`
+	CompanyStatus   string    `json:"company_status"`
+	CompanySubtype  string    `json:"company_subtype"`
+	CompanyType     string    `json:"company_type"`
+	DateOfCessation string    `json:"date_of_cessation"`
+	DateOfCreation  string    `json:"date_of_creation"`
+	Kind            string    `json:"kind"`
+	Links           struct {
+		CompanyProfile string `json:"company_profile"`
+	} `json:"links"`
+	RegisteredOfficeAddress Address `json:"registered_office_address"`
+	SicCodes                []SIC   `json:"sic_codes"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/alphabeticalcompanysearch
+type AlphabeticalCompanySearch struct {
+	Etag   string                          `json:"etag"`
+	Items  []AlphabeticalCompanySearchItem `json:"items"`
+	Kind   string                          `json:"kind"`
+	TopHit AlphabeticalCompanySearchItem   `json:"top_hit"`
+}
+
+// AlphabeticalCompanySearchItem is a single company returned by an
+// alphabetical search
+type AlphabeticalCompanySearchItem struct {
+	CompanyName   string    `json:"company_name"`
+	CompanyNumber CompanyNo `json:"company_number"`
+	CompanyStatus string    `json:"company_status"`
+	CompanyType   string    `json:"company_type"`
+	Kind          string    `json:"kind"`
+	Links         struct {
+		CompanyProfile string `json:"company_profile"`
+	} `json:"links"`
+	OrderedAlphaKeyWithID string `json:"ordered_alpha_key_with_id"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/resources/dissolvedcompanysearch
+type DissolvedCompanySearch struct {
+	Etag   string                       `json:"etag"`
+	Hits   int                          `json:"hits"`
+	Items  []DissolvedCompanySearchItem `json:"items"`
+	Kind   string                       `json:"kind"`
+	TopHit DissolvedCompanySearchItem   `json:"top_hit"`
+}
+
+// DissolvedCompanySearchItem is a single company returned by a dissolved
+// company search
+type DissolvedCompanySearchItem struct {
+	CompanyName                string    `json:"company_name"`
+	CompanyNumber              CompanyNo `json:"company_number"`
+	CompanyStatus              string    `json:"company_status"`
+	DateOfCessation            string    `json:"date_of_cessation"`
+	DateOfCreation             string    `json:"date_of_creation"`
+	Kind                       string    `json:"kind"`
+	MatchedPreviousCompanyName struct {
+		CeasedOn      string `json:"ceased_on"`
+		EffectiveFrom string `json:"effective_from"`
+		Name          string `json:"name"`
+	} `json:"matched_previous_company_name"`
+	OrderedAlphaKeyWithID string `json:"ordered_alpha_key_with_id"`
+	PreviousCompanyNames  []struct {
+		CeasedOn      string    `json:"ceased_on"`
+		CompanyNumber CompanyNo `json:"company_number"`
+		EffectiveFrom string    `json:"effective_from"`
+		Name          string    `json:"name"`
+	} `json:"previous_company_names"`
+	RegisteredOfficeAddress Address `json:"registered_office_address"`
+}
+
+// https://developer-specs.company-information.service.gov.uk/document-api/resources/documentmetadata
+type DocumentMetadata struct {
+	Barcode       string    `json:"barcode"`
+	Category      string    `json:"category"`
+	CompanyNumber CompanyNo `json:"company_number"`
+	CreatedAt     string    `json:"created_at"`
+	Etag          string    `json:"etag"`
+	Links         struct {
+		Document string `json:"document"`
+		Self     string `json:"self"`
+	} `json:"links"`
+	Pages     int `json:"pages"`
+	Resources map[string]struct {
+		ContentLength int64  `json:"content_length"`
+		CreatedAt     string `json:"created_at"`
+		UpdatedAt     string `json:"updated_at"`
+	} `json:"resources"`
+	SignificantDate     string `json:"significant_date"`
+	SignificantDateType string `json:"significant_date_type"`
+}
+
+// ContentTypes returns the content types the document is available in
+func (m *DocumentMetadata) ContentTypes() []string {
+	types := make([]string, 0, len(m.Resources))
+	for t := range m.Resources {
+		types = append(types, t)
+	}
+
+	sort.Strings(types)
+
+	return types
+}
+
+// https://developer-specs.company-information.service.gov.uk/streaming-api/guides/overview
+type StreamEvent struct {
+	Data  json.RawMessage `json:"data"`
+	Event struct {
+		FieldsChanged []string `json:"fields_changed"`
+		PublishedAt   string   `json:"published_at"`
+		Timepoint     int64    `json:"timepoint"`
+		Type          string   `json:"type"`
+	} `json:"event"`
+	ResourceID   string `json:"resource_id"`
+	ResourceKind string `json:"resource_kind"`
+	ResourceURI  string `json:"resource_uri"`
+}
+
+// Decode decodes the event's data into the passed interface
+func (m *StreamEvent) Decode(dest interface{}) error {
+	return json.Unmarshal(m.Data, dest)
+}
+
+// CompanyProfile decodes the data of an event from the companies stream
+func (m *StreamEvent) CompanyProfile() (*CompanyProfile, error) {
+	c := &CompanyProfile{}
+	return c, m.Decode(c)
+}
+
+// FilingHistoryItem decodes the data of an event from the filings stream
+func (m *StreamEvent) FilingHistoryItem() (*FilingHistoryItem, error) {
+	f := &FilingHistoryItem{}
+	return f, m.Decode(f)
+}
+
+// OfficerSummary decodes the data of an event from the officers stream
+func (m *StreamEvent) OfficerSummary() (*OfficerSummary, error) {
+	o := &OfficerSummary{}
+	return o, m.Decode(o)
+}
+
+// PSC decodes the data of an event from the persons with significant control
+// stream
+func (m *StreamEvent) PSC() (*PSC, error) {
+	p := &PSC{}
+	return p, m.Decode(p)
+}
+
+// PSCStatement decodes the data of an event from the persons with significant
+// control statements stream
+func (m *StreamEvent) PSCStatement() (*PSCStatement, error) {
+	p := &PSCStatement{}
+	return p, m.Decode(p)
+}
+
+// ChargeDetails decodes the data of an event from the charges stream
+func (m *StreamEvent) ChargeDetails() (*ChargeDetails, error) {
+	c := &ChargeDetails{}
+	return c, m.Decode(c)
+}
+
+// CompanyInsolvency decodes the data of an event from the insolvency cases
+// stream
+func (m *StreamEvent) CompanyInsolvency() (*CompanyInsolvency, error) {
+	i := &CompanyInsolvency{}
+	return i, m.Decode(i)
+}
+
+// NaturalDisqualifiedOfficer decodes the data of an event from the
+// disqualified officers stream with a ResourceKind of
+// "disqualified-officer-natural"
+func (m *StreamEvent) NaturalDisqualifiedOfficer() (*NaturalDisqualifiedOfficer, error) {
+	d := &NaturalDisqualifiedOfficer{}
+	return d, m.Decode(d)
+}
+
+// CorporateDisqualifiedOfficer decodes the data of an event from the
+// disqualified officers stream with a ResourceKind of
+// "disqualified-officer-corporate"
+func (m *StreamEvent) CorporateDisqualifiedOfficer() (*CorporateDisqualifiedOfficer, error) {
+	d := &CorporateDisqualifiedOfficer{}
+	return d, m.Decode(d)
+}
+
+// CompanyExemptions decodes the data of an event from the company exemptions
+// stream
+func (m *StreamEvent) CompanyExemptions() (*CompanyExemptions, error) {
+	e := &CompanyExemptions{}
+	return e, m.Decode(e)
+}
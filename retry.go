@@ -0,0 +1,178 @@
+package comphouse
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default values used by DefaultRetryPolicy
+const (
+	DefaultMaxAttempts = 4
+	DefaultMinBackoff  = time.Millisecond * 500
+	DefaultMaxBackoff  = time.Second * 30
+)
+
+// RetryPolicy controls how a Client retries requests that fail because of
+// rate limiting, server errors or transport errors
+//
+// Requests that receive a 429 response are always retried. Idempotent requests
+// are also retried when they receive a 502, 503 or 504 response or when the
+// request could not be sent at all. The delay between attempts grows
+// exponentially from MinBackoff up to MaxBackoff with random jitter applied,
+// unless the response includes a Retry-After or X-Ratelimit-Reset header
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the first.
+	// Values less than 2 disable retries
+	MaxAttempts int
+	MinBackoff  time.Duration
+	MaxBackoff  time.Duration
+}
+
+// DefaultRetryPolicy creates a new RetryPolicy using sensible defaults
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: DefaultMaxAttempts,
+		MinBackoff:  DefaultMinBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// Backoff returns the delay before the specified retry attempt, where the
+// first retry is attempt 1. The delay doubles with each attempt and has
+// jitter applied so that concurrent clients don't retry in lockstep
+func (m *RetryPolicy) Backoff(attempt int) time.Duration {
+	d := m.MinBackoff
+	for i := 1; i < attempt && d < m.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if m.MaxBackoff > 0 && d > m.MaxBackoff {
+		d = m.MaxBackoff
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	half := d / 2
+
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// retry decides whether a request should be retried after the specified
+// attempt and returns how long to wait before doing so
+func (m *RetryPolicy) retry(attempt int, req *http.Request, resp *http.Response, err error) (time.Duration, bool) {
+	if m == nil || attempt >= m.MaxAttempts || req.Context().Err() != nil {
+		return 0, false
+	}
+
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+
+	if err != nil {
+		return m.Backoff(attempt), isIdempotent(req.Method)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		if d, ok := retryAfter(resp.Header, time.Now()); ok {
+			return d, true
+		}
+
+		return m.Backoff(attempt), true
+
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		if !isIdempotent(req.Method) {
+			return 0, false
+		}
+
+		if d, ok := retryAfter(resp.Header, time.Now()); ok {
+			return d, true
+		}
+
+		return m.Backoff(attempt), true
+	}
+
+	return 0, false
+}
+
+// retryAfter determines how long the server asked us to wait before retrying
+// using the Retry-After header, falling back to the X-Ratelimit-Reset header
+// which holds the unix time at which the rate limit window resets
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	if v := header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return nonNegative(time.Duration(secs) * time.Second), true
+		}
+
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+
+	if v := header.Get("X-Ratelimit-Reset"); v != "" {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return nonNegative(time.Unix(secs, 0).Sub(now)), true
+		}
+	}
+
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+
+	return d
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	}
+
+	return false
+}
+
+// rewind prepares a request to be sent again, recreating its body if needed
+func rewind(req *http.Request) (*http.Request, error) {
+	next := req.Clone(req.Context())
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		next.Body = body
+	}
+
+	return next, nil
+}
+
+// discard drains and closes a response body so the underlying connection can
+// be reused
+func discard(resp *http.Response) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	resp.Body.Close()
+}
+
+// sleep waits for the specified duration or until the context is done
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
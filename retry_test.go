@@ -0,0 +1,208 @@
+package comphouse
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  time.Millisecond * 5,
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	assert := assert.New(t)
+
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: time.Second * 10}
+
+	for attempt, max := range []time.Duration{time.Second, time.Second * 2, time.Second * 4, time.Second * 8, time.Second * 10, time.Second * 10} {
+		d := p.Backoff(attempt + 1)
+
+		assert.GreaterOrEqual(int64(d), int64(max/2))
+		assert.LessOrEqual(int64(d), int64(max))
+	}
+
+	assert.Zero((&RetryPolicy{}).Backoff(1))
+}
+
+func TestRetryAfter(t *testing.T) {
+	type test struct {
+		name   string
+		header http.Header
+		exp    time.Duration
+		ok     bool
+	}
+
+	now := time.Date(2021, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []test{
+		{"seconds", http.Header{"Retry-After": {"3"}}, time.Second * 3, true},
+		{"http date", http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}}, time.Minute, true},
+		{"rate limit reset", http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(time.Second*90).Unix(), 10)}}, time.Second * 90, true},
+		{"in the past", http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(now.Add(-time.Hour).Unix(), 10)}}, 0, true},
+		{"invalid", http.Header{"Retry-After": {"soon"}}, 0, false},
+		{"missing", http.Header{}, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			d, ok := retryAfter(test.header, now)
+
+			assert.Equal(test.ok, ok)
+			assert.Equal(test.exp, d)
+		})
+	}
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		if requests < 3 {
+			w.WriteHeader(503)
+			return
+		}
+
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	var hooks int
+
+	c.Retry = testRetryPolicy()
+	c.Hooks.AfterRequest = append(c.Hooks.AfterRequest, func(_ *http.Response) {
+		hooks++
+	})
+
+	resp, err := c.Get("")
+
+	assert.NoError(err)
+	assert.NotNil(resp)
+	assert.Equal(3, requests)
+	assert.Equal(3, hooks)
+}
+
+func TestClientRetriesTooManyRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(429)
+			return
+		}
+
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	c.Retry = testRetryPolicy()
+
+	resp, err := c.Do(http.MethodPost, "", strings.NewReader("body"))
+
+	assert.NoError(err)
+	assert.NotNil(resp)
+	assert.Equal(2, requests)
+}
+
+func TestClientRetryGivesUp(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(429)
+	})
+
+	defer ts.Close()
+
+	c.Retry = testRetryPolicy()
+
+	resp, err := c.Get("")
+
+	assert.Nil(resp)
+	assert.ErrorIs(err, ErrTooManyRequests)
+	assert.Equal(3, requests)
+}
+
+func TestClientDoesNotRetryNonIdempotentRequests(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(503)
+	})
+
+	defer ts.Close()
+
+	c.Retry = testRetryPolicy()
+
+	resp, err := c.Do(http.MethodPost, "", nil)
+
+	assert.Nil(resp)
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+	assert.Equal(1, requests)
+}
+
+func TestClientDoesNotRetryWithoutPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(503)
+	})
+
+	defer ts.Close()
+
+	_, err := c.Get("")
+
+	assert.ErrorIs(err, ErrUnexpectedStatus)
+	assert.Equal(1, requests)
+}
+
+func TestClientRetryRespectsContext(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(429)
+	})
+
+	defer ts.Close()
+
+	c.Retry = testRetryPolicy()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	resp, err := c.GetContext(ctx, "")
+
+	assert.Nil(resp)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
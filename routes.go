@@ -0,0 +1,108 @@
+package comphouse
+
+import (
+	"context"
+	"strings"
+)
+
+// endpointTemplates are the path templates of every API endpoint the Client
+// provides methods for
+var endpointTemplates = []string{
+	"/company/{company_number}",
+	"/company/{company_number}/registered-office-address",
+	"/company/{company_number}/officers",
+	"/company/{company_number}/appointments/{appointment_id}",
+	"/company/{company_number}/registers",
+	"/company/{company_number}/charges",
+	"/company/{company_number}/charges/{charge_id}",
+	"/company/{company_number}/filing-history",
+	"/company/{company_number}/filing-history/{transaction_id}",
+	"/company/{company_number}/persons-with-significant-control",
+	"/company/{company_number}/persons-with-significant-control/individual/{psc_id}",
+	"/company/{company_number}/persons-with-significant-control/individual-beneficial-owner/{psc_id}",
+	"/company/{company_number}/persons-with-significant-control/corporate-entity/{psc_id}",
+	"/company/{company_number}/persons-with-significant-control/corporate-entity-beneficial-owner/{psc_id}",
+	"/company/{company_number}/persons-with-significant-control/legal-person/{psc_id}",
+	"/company/{company_number}/persons-with-significant-control/legal-person-beneficial-owner/{psc_id}",
+	"/company/{company_number}/persons-with-significant-control/super-secure/{super_secure_id}",
+	"/company/{company_number}/persons-with-significant-control/super-secure-beneficial-owner/{super_secure_id}",
+	"/company/{company_number}/persons-with-significant-control-statements",
+	"/company/{company_number}/persons-with-significant-control-statements/{statement_id}",
+	"/company/{company_number}/insolvency",
+	"/company/{company_number}/exemptions",
+	"/company/{company_number}/uk-establishments",
+	"/officers/{officer_id}/appointments",
+	"/disqualified-officers/natural/{officer_id}",
+	"/disqualified-officers/corporate/{officer_id}",
+	"/search",
+	"/search/companies",
+	"/search/officers",
+	"/search/disqualified-officers",
+	"/advanced-search/companies",
+	"/alphabetical-search/companies",
+	"/dissolved-search/companies",
+	"/document/{document_id}",
+	"/document/{document_id}/content",
+}
+
+// EndpointTemplate returns the template of the API endpoint a request path
+// belongs to, such as "/company/{company_number}/officers" for
+// "/company/00000001/officers". Templates have a bounded number of values so
+// they are suitable for use as metric labels or span names. An empty string
+// is returned for unknown paths
+func EndpointTemplate(path string) string {
+	for _, template := range endpointTemplates {
+		if matchPathTemplate(template, path) {
+			return template
+		}
+	}
+
+	return ""
+}
+
+// matchPathTemplate reports whether path matches a template such as
+// "/company/{company_number}"
+func matchPathTemplate(template, path string) bool {
+	t := strings.Split(strings.Trim(template, "/"), "/")
+	p := strings.Split(strings.Trim(path, "/"), "/")
+
+	if len(t) != len(p) {
+		return false
+	}
+
+	for i := range t {
+		if strings.HasPrefix(t[i], "{") && strings.HasSuffix(t[i], "}") {
+			continue
+		}
+
+		if t[i] != p[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// countPathWildcards returns the number of segments in a path template that
+// match any value
+func countPathWildcards(template string) int {
+	n := 0
+
+	for _, s := range strings.Split(strings.Trim(template, "/"), "/") {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			n++
+		}
+	}
+
+	return n
+}
+
+type attemptKey struct{}
+
+// AttemptFromContext returns which attempt of a request is being sent. It
+// can be used by Middleware to tell retries apart from new requests and
+// returns zero for contexts of requests that weren't sent by a Client
+func AttemptFromContext(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
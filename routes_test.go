@@ -0,0 +1,85 @@
+package comphouse
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPathTemplate(t *testing.T) {
+	type test struct {
+		template string
+		path     string
+		exp      bool
+	}
+
+	tests := []test{
+		{"/company/{company_number}", "/company/00000001", true},
+		{"/company/{company_number}/", "/company/00000001", true},
+		{"/company/{company_number}", "/company/00000001/officers", false},
+		{"/company/{company_number}/officers", "/company/00000001/officers", true},
+		{"/company/{company_number}/officers", "/company/00000001/charges", false},
+		{"/search/companies", "/search/companies", true},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.exp, matchPathTemplate(test.template, test.path), test.template+" "+test.path)
+	}
+}
+
+func TestEndpointTemplate(t *testing.T) {
+	type test struct {
+		inp string
+		exp string
+	}
+
+	tests := []test{
+		{"/company/00000001", "/company/{company_number}"},
+		{"/company/SC000001/officers", "/company/{company_number}/officers"},
+		{"/company/00000001/persons-with-significant-control/individual/abc", "/company/{company_number}/persons-with-significant-control/individual/{psc_id}"},
+		{"/officers/abc/appointments", "/officers/{officer_id}/appointments"},
+		{"/search/companies", "/search/companies"},
+		{"/document/abc/content", "/document/{document_id}/content"},
+		{"/unknown", ""},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.exp, EndpointTemplate(test.inp), test.inp)
+	}
+}
+
+func TestAttemptFromContext(t *testing.T) {
+	assert := assert.New(t)
+
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		if requests++; requests == 1 {
+			w.WriteHeader(503)
+			return
+		}
+
+		w.WriteHeader(200)
+	})
+
+	defer ts.Close()
+
+	c.Retry = &RetryPolicy{MaxAttempts: 2}
+
+	var attempts []int
+
+	c.Use(func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts = append(attempts, AttemptFromContext(req.Context()))
+			return next.RoundTrip(req)
+		})
+	})
+
+	_, err := c.Get("")
+
+	assert.NoError(err)
+	assert.Equal([]int{1, 2}, attempts)
+	assert.Zero(AttemptFromContext(context.Background()))
+}
@@ -0,0 +1,300 @@
+package comphouse
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Default values used when creating a new Scanner
+const (
+	DefaultScanConcurrency = 4
+	DefaultCheckpointEvery = 100
+)
+
+// Checkpoint stores the progress of a Scanner so an interrupted scan can be
+// resumed. Load returns nil and no error if no progress has been saved
+type Checkpoint interface {
+	Load() (CompanyNumber, error)
+	Save(companyNo CompanyNumber) error
+}
+
+// FileCheckpoint is a Checkpoint storing the last company number scanned in
+// a file. The file is replaced atomically on each save so a crash can't leave
+// it partially written
+type FileCheckpoint struct {
+	Path string
+}
+
+// NewFileCheckpoint creates a new FileCheckpoint storing progress in path
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{Path: path}
+}
+
+// Load reads the company number stored in the checkpoint file. It returns nil
+// and no error if the file doesn't exist
+func (m *FileCheckpoint) Load() (CompanyNumber, error) {
+	b, err := os.ReadFile(m.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return CompanyNumberFromString(strings.TrimSpace(string(b)))
+}
+
+// Save writes a company number to the checkpoint file
+func (m *FileCheckpoint) Save(companyNo CompanyNumber) error {
+	f, err := os.CreateTemp(filepath.Dir(m.Path), filepath.Base(m.Path)+".tmp-")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(f.Name())
+
+	if _, err := f.WriteString(companyNo.String() + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), m.Path)
+}
+
+// Scanner walks a range of company numbers fetching the profile of each
+// company. Numbers that don't belong to a company are skipped. Requests are
+// sent concurrently but are still paced by the Client's RateLimiter, so
+// raising Concurrency only helps while the rate limit hasn't been reached
+//
+// Progress is saved to Checkpoint, if set, as the highest company found below
+// which every number has been scanned. Trailing numbers that don't belong to
+// a company aren't saved until the whole range has been scanned, as they may
+// yet be issued. A scan started with the same Checkpoint resumes after the
+// saved number, so some numbers may be fetched twice but none are missed
+type Scanner struct {
+	Client *Client
+	Range  CompanyNumberRange
+
+	// Concurrency is the number of profiles fetched at once
+	Concurrency int
+
+	// Checkpoint, if set, is loaded when the scan starts and saved as it
+	// progresses and when it stops
+	Checkpoint Checkpoint
+
+	// CheckpointEvery is how many numbers are scanned between saves of the
+	// Checkpoint
+	CheckpointEvery int
+
+	// MaxConsecutiveMisses, if greater than zero, stops the scan once this
+	// many consecutive numbers don't belong to a company. This allows open
+	// ended ranges to be used to find the most recently incorporated companies
+	MaxConsecutiveMisses int
+}
+
+// result of scanning a single company number
+type scanResult struct {
+	index     int
+	companyNo CompanyNumber
+	profile   *CompanyProfile
+	err       error
+}
+
+// Scan fetches the profile of every company in the range and calls handler
+// with each one in the order the responses are received. Handler is never
+// called concurrently. Scan returns when the range is exhausted, the context
+// is done, a request fails with an error other than ErrNotFound or handler
+// returns an error. The Checkpoint is saved before Scan returns in every case
+func (m *Scanner) Scan(ctx context.Context, handler func(*CompanyProfile) error) error {
+	if err := m.Range.Validate(); err != nil {
+		return err
+	}
+
+	r := m.Range
+
+	if m.Checkpoint != nil {
+		last, err := m.Checkpoint.Load()
+		if err != nil {
+			return err
+		}
+
+		if last != nil {
+			r = r.After(last)
+		}
+	}
+
+	concurrency := m.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultScanConcurrency
+	}
+
+	every := m.CheckpointEvery
+	if every <= 0 {
+		every = DefaultCheckpointEvery
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan scanResult)
+	results := make(chan scanResult)
+
+	go func() {
+		defer close(jobs)
+
+		i := 0
+
+		r.Each(func(companyNo CompanyNumber) bool {
+			select {
+			case jobs <- scanResult{index: i, companyNo: companyNo}:
+				i++
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		})
+	}()
+
+	var wg sync.WaitGroup
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for job := range jobs {
+				job.profile, job.err = m.Client.Company(job.companyNo).ProfileContext(ctx)
+				if errors.Is(job.err, ErrNotFound) {
+					job.err = nil
+				}
+
+				select {
+				case results <- job:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		err     error
+		pending = make(map[int]scanResult)
+		next    int
+		saved   int
+		misses  int
+		stopped bool
+		last    CompanyNumber
+		hit     CompanyNumber
+		hitNext int
+	)
+
+	// save checkpoints companyNo, the number before index end
+	save := func(companyNo CompanyNumber, end int) error {
+		if m.Checkpoint == nil || companyNo == nil || end <= saved {
+			return nil
+		}
+
+		saved = end
+
+		return m.Checkpoint.Save(companyNo)
+	}
+
+	for res := range results {
+		if err != nil || stopped || ctx.Err() != nil {
+			continue
+		}
+
+		if res.err != nil {
+			err = res.err
+			cancel()
+			continue
+		}
+
+		if res.profile != nil {
+			if err = handler(res.profile); err != nil {
+				cancel()
+				continue
+			}
+		}
+
+		pending[res.index] = res
+
+		// advance past every contiguous number that has been scanned
+		for {
+			done, ok := pending[next]
+			if !ok {
+				break
+			}
+
+			delete(pending, next)
+
+			next++
+			last = done.companyNo
+
+			misses++
+			if done.profile != nil {
+				misses = 0
+				hit, hitNext = done.companyNo, next
+			}
+
+			if m.MaxConsecutiveMisses > 0 && misses >= m.MaxConsecutiveMisses {
+				stopped = true
+				cancel()
+				break
+			}
+		}
+
+		if next-saved >= every {
+			if err = save(hit, hitNext); err != nil {
+				cancel()
+			}
+		}
+	}
+
+	if err == nil && !stopped {
+		err = ctx.Err()
+	}
+
+	// only a range that was scanned to the end is checkpointed past its last
+	// company, otherwise a scan stopped by misses would never revisit them
+	var saveErr error
+
+	if err == nil && !stopped {
+		saveErr = save(last, next)
+	} else {
+		saveErr = save(hit, hitNext)
+	}
+
+	if err == nil {
+		err = saveErr
+	}
+
+	return err
+}
+
+// Scanner creates a new Scanner that can be used to fetch the profile of
+// every company in a range of company numbers
+func (m *Client) Scanner(r CompanyNumberRange) *Scanner {
+	return &Scanner{
+		Client:          m,
+		Range:           r,
+		Concurrency:     DefaultScanConcurrency,
+		CheckpointEvery: DefaultCheckpointEvery,
+	}
+}
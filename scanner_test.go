@@ -0,0 +1,234 @@
+package comphouse
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestScanServer creates a server responding with a profile for the
+// provided company numbers and a 404 for all others. Every requested company
+// number is recorded
+func createTestScanServer(companies ...string) (*httptest.Server, *Client, func() []string) {
+	var (
+		mu        sync.Mutex
+		requested []string
+	)
+
+	exists := make(map[string]bool)
+	for _, c := range companies {
+		exists[c] = true
+	}
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		companyNo := strings.TrimPrefix(r.URL.Path, "/company/")
+
+		mu.Lock()
+		requested = append(requested, companyNo)
+		mu.Unlock()
+
+		if !exists[companyNo] {
+			w.WriteHeader(404)
+			return
+		}
+
+		w.Write([]byte(`{"company_number": "` + companyNo + `"}`))
+	})
+
+	return ts, c, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		sort.Strings(requested)
+
+		return append([]string(nil), requested...)
+	}
+}
+
+func scanNumbers(s *Scanner, ctx context.Context) ([]string, error) {
+	var found []string
+
+	err := s.Scan(ctx, func(p *CompanyProfile) error {
+		found = append(found, p.CompanyNumber.String())
+		return nil
+	})
+
+	sort.Strings(found)
+
+	return found, err
+}
+
+func TestScannerSkipsMissingCompanies(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c, requested := createTestScanServer("00000002", "00000003", "00000007")
+	defer ts.Close()
+
+	r, err := NewCompanyNumberRange(EnglishCompanyNo(1), EnglishCompanyNo(8))
+	assert.NoError(err)
+
+	found, err := scanNumbers(c.Scanner(r), context.Background())
+
+	assert.NoError(err)
+	assert.Equal([]string{"00000002", "00000003", "00000007"}, found)
+	assert.Len(requested(), 8)
+}
+
+func TestScannerStopsAfterConsecutiveMisses(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c, _ := createTestScanServer("00000001", "00000003")
+	defer ts.Close()
+
+	r, err := NewCompanyNumberRange(EnglishCompanyNo(1), EnglishCompanyNo(99999999))
+	assert.NoError(err)
+
+	s := c.Scanner(r)
+	s.Concurrency = 1
+	s.MaxConsecutiveMisses = 3
+
+	found, err := scanNumbers(s, context.Background())
+
+	assert.NoError(err)
+	assert.Equal([]string{"00000001", "00000003"}, found)
+}
+
+func TestScannerReturnsErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(401)
+	})
+
+	defer ts.Close()
+
+	r, err := NewCompanyNumberRange(EnglishCompanyNo(1), EnglishCompanyNo(100))
+	assert.NoError(err)
+
+	_, err = scanNumbers(c.Scanner(r), context.Background())
+	assert.ErrorIs(err, ErrUnauthorized)
+
+	_, err = scanNumbers(c.Scanner(CompanyNumberRange{}), context.Background())
+	assert.ErrorIs(err, ErrInvalidRange)
+}
+
+func TestScannerResumesFromCheckpoint(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "comphouse-scan-")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	ts, c, requested := createTestScanServer("00000002", "00000005", "00000009")
+	defer ts.Close()
+
+	r, err := NewCompanyNumberRange(EnglishCompanyNo(1), EnglishCompanyNo(10))
+	assert.NoError(err)
+
+	checkpoint := NewFileCheckpoint(filepath.Join(dir, "checkpoint"))
+
+	s := c.Scanner(r)
+	s.Concurrency = 1
+	s.CheckpointEvery = 2
+	s.Checkpoint = checkpoint
+
+	// interrupt the scan when the second company is found
+	ctx, cancel := context.WithCancel(context.Background())
+
+	err = s.Scan(ctx, func(p *CompanyProfile) error {
+		if p.CompanyNumber.String() == "00000005" {
+			cancel()
+		}
+
+		return nil
+	})
+
+	assert.ErrorIs(err, context.Canceled)
+
+	last, err := checkpoint.Load()
+	assert.NoError(err)
+	assert.Equal("00000005", last.String())
+
+	found, err := scanNumbers(s, context.Background())
+
+	assert.NoError(err)
+	assert.Equal([]string{"00000009"}, found)
+	// numbers in flight when the scan was interrupted may be requested twice
+	assert.Subset(requested(), []string{
+		"00000001", "00000002", "00000003", "00000004", "00000005",
+		"00000006", "00000007", "00000008", "00000009", "00000010",
+	}, requested())
+
+	last, err = checkpoint.Load()
+	assert.NoError(err)
+	assert.Equal("00000010", last.String())
+}
+
+func TestScannerCheckpointsLastCompanyWhenStoppedByMisses(t *testing.T) {
+	assert := assert.New(t)
+
+	dir, err := os.MkdirTemp("", "comphouse-scan-")
+	assert.NoError(err)
+
+	defer os.RemoveAll(dir)
+
+	r, err := NewCompanyNumberRange(EnglishCompanyNo(1), EnglishCompanyNo(99999999))
+	assert.NoError(err)
+
+	checkpoint := NewFileCheckpoint(filepath.Join(dir, "checkpoint"))
+
+	scan := func(companies ...string) []string {
+		ts, c, _ := createTestScanServer(companies...)
+		defer ts.Close()
+
+		s := c.Scanner(r)
+		s.Concurrency = 2
+		s.CheckpointEvery = 1
+		s.Checkpoint = checkpoint
+		s.MaxConsecutiveMisses = 5
+
+		found, err := scanNumbers(s, context.Background())
+		assert.NoError(err)
+
+		return found
+	}
+
+	assert.Equal([]string{"00000001", "00000002", "00000003"}, scan("00000001", "00000002", "00000003"))
+
+	last, err := checkpoint.Load()
+	assert.NoError(err)
+	assert.Equal("00000003", last.String())
+
+	// companies incorporated since the last sweep are found by the next one
+	found := scan("00000001", "00000002", "00000003", "00000004", "00000006")
+	assert.Equal([]string{"00000004", "00000006"}, found)
+
+	last, err = checkpoint.Load()
+	assert.NoError(err)
+	assert.Equal("00000006", last.String())
+
+	// a sweep finding nothing leaves the checkpoint where it was
+	assert.Empty(scan("00000001", "00000002", "00000003", "00000004", "00000006"))
+
+	last, err = checkpoint.Load()
+	assert.NoError(err)
+	assert.Equal("00000006", last.String())
+}
+
+func TestFileCheckpointMissingFile(t *testing.T) {
+	assert := assert.New(t)
+
+	last, err := NewFileCheckpoint(filepath.Join(os.TempDir(), "comphouse-missing-checkpoint")).Load()
+
+	assert.NoError(err)
+	assert.Nil(last)
+}
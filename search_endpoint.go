@@ -2,12 +2,18 @@ package comphouse
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
+// MaxAdvancedSearchSize is the maximum number of results the advanced search
+// API will return for a single request
+const MaxAdvancedSearchSize = 5000
+
 // SearchEndpoint is a struct that can be used to perform searches using the
 // Companies House REST API
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search
@@ -43,6 +49,217 @@ func (m SearchParams) Encode() string {
 	return buff.String()
 }
 
+// AdvancedSearchParams are used as input to the advanced company search. Zero
+// values are omitted from the request
+type AdvancedSearchParams struct {
+	CompanyNameIncludes string
+	CompanyNameExcludes string
+	CompanyStatus       []CompanyStatus
+	CompanySubtype      CompanySubtype
+	CompanyType         []CompanyType
+	DissolvedFrom       time.Time
+	DissolvedTo         time.Time
+	IncorporatedFrom    time.Time
+	IncorporatedTo      time.Time
+	Location            string
+	SicCodes            []SIC
+	Size                int
+	StartIndex          int
+}
+
+// Validate checks the AdvancedSearchParams for values the API would reject
+// or combinations of filters that can never match a company. The returned
+// error wraps ErrInvalidSearchParams
+func (m AdvancedSearchParams) Validate() error {
+	invalid := func(format string, args ...interface{}) error {
+		return fmt.Errorf("%w: %s", ErrInvalidSearchParams, fmt.Sprintf(format, args...))
+	}
+
+	if m.Size < 0 || m.Size > MaxAdvancedSearchSize {
+		return invalid("size must be between 0 and %d", MaxAdvancedSearchSize)
+	}
+
+	if m.StartIndex < 0 {
+		return invalid("start_index must not be negative")
+	}
+
+	if m.CompanyNameIncludes != "" && strings.EqualFold(m.CompanyNameIncludes, m.CompanyNameExcludes) {
+		return invalid("company_name_includes and company_name_excludes are the same")
+	}
+
+	if !m.IncorporatedFrom.IsZero() && !m.IncorporatedTo.IsZero() && m.IncorporatedFrom.After(m.IncorporatedTo) {
+		return invalid("incorporated_from is after incorporated_to")
+	}
+
+	if !m.DissolvedFrom.IsZero() && !m.DissolvedTo.IsZero() && m.DissolvedFrom.After(m.DissolvedTo) {
+		return invalid("dissolved_from is after dissolved_to")
+	}
+
+	if !m.DissolvedFrom.IsZero() || !m.DissolvedTo.IsZero() {
+		dissolved := len(m.CompanyStatus) == 0
+
+		for _, status := range m.CompanyStatus {
+			if status == CompanyStatusDissolved {
+				dissolved = true
+			}
+		}
+
+		if !dissolved {
+			return invalid("dissolved dates require company_status to include %s", CompanyStatusDissolved)
+		}
+
+		if !m.IncorporatedFrom.IsZero() && !m.DissolvedTo.IsZero() && m.IncorporatedFrom.After(m.DissolvedTo) {
+			return invalid("incorporated_from is after dissolved_to")
+		}
+	}
+
+	for _, sic := range m.SicCodes {
+		if !sic.Valid() {
+			return invalid("unknown SIC code %q", sic)
+		}
+	}
+
+	return nil
+}
+
+// Encode converts AdvancedSearchParams into an escaped string suitable for use
+// in query strings
+func (m AdvancedSearchParams) Encode() string {
+	const date = "2006-01-02"
+
+	q := url.Values{}
+
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+
+	setDate := func(key string, value time.Time) {
+		if !value.IsZero() {
+			q.Set(key, value.Format(date))
+		}
+	}
+
+	set("company_name_includes", m.CompanyNameIncludes)
+	set("company_name_excludes", m.CompanyNameExcludes)
+	set("company_subtype", string(m.CompanySubtype))
+	set("location", m.Location)
+
+	for _, status := range m.CompanyStatus {
+		q.Add("company_status", string(status))
+	}
+
+	for _, typ := range m.CompanyType {
+		q.Add("company_type", string(typ))
+	}
+
+	for _, sic := range m.SicCodes {
+		q.Add("sic_codes", string(sic))
+	}
+
+	setDate("dissolved_from", m.DissolvedFrom)
+	setDate("dissolved_to", m.DissolvedTo)
+	setDate("incorporated_from", m.IncorporatedFrom)
+	setDate("incorporated_to", m.IncorporatedTo)
+
+	if m.Size > 0 {
+		q.Set("size", strconv.Itoa(m.Size))
+	}
+
+	if m.StartIndex > 0 {
+		q.Set("start_index", strconv.Itoa(m.StartIndex))
+	}
+
+	return q.Encode()
+}
+
+// AlphabeticalSearchParams are used as input to the alphabetical company
+// search. SearchAbove and SearchBelow take the OrderedAlphaKeyWithID of a
+// result and are used to page through the names either side of it
+type AlphabeticalSearchParams struct {
+	Query       string
+	SearchAbove string
+	SearchBelow string
+	Size        int
+}
+
+// Encode converts AlphabeticalSearchParams into an escaped string suitable for
+// use in query strings
+func (m AlphabeticalSearchParams) Encode() string {
+	q := url.Values{}
+
+	q.Set("q", m.Query)
+
+	if m.SearchAbove != "" {
+		q.Set("search_above", m.SearchAbove)
+	}
+
+	if m.SearchBelow != "" {
+		q.Set("search_below", m.SearchBelow)
+	}
+
+	if m.Size > 0 {
+		q.Set("size", strconv.Itoa(m.Size))
+	}
+
+	return q.Encode()
+}
+
+// DissolvedSearchType determines how the dissolved company search matches
+// company names
+type DissolvedSearchType string
+
+// Search types supported by the dissolved company search
+const (
+	DissolvedSearchAlphabetical          DissolvedSearchType = "alphabetical"
+	DissolvedSearchBestMatch             DissolvedSearchType = "best-match"
+	DissolvedSearchPreviousNameDissolved DissolvedSearchType = "previous-name-dissolved"
+)
+
+// DissolvedSearchParams are used as input to the dissolved company search.
+// SearchAbove and SearchBelow are only used by alphabetical searches
+type DissolvedSearchParams struct {
+	Query       string
+	SearchType  DissolvedSearchType
+	SearchAbove string
+	SearchBelow string
+	Size        int
+	StartIndex  int
+}
+
+// Encode converts DissolvedSearchParams into an escaped string suitable for
+// use in query strings. The search type defaults to alphabetical
+func (m DissolvedSearchParams) Encode() string {
+	q := url.Values{}
+
+	q.Set("q", m.Query)
+
+	if m.SearchType != "" {
+		q.Set("search_type", string(m.SearchType))
+	} else {
+		q.Set("search_type", string(DissolvedSearchAlphabetical))
+	}
+
+	if m.SearchAbove != "" {
+		q.Set("search_above", m.SearchAbove)
+	}
+
+	if m.SearchBelow != "" {
+		q.Set("search_below", m.SearchBelow)
+	}
+
+	if m.Size > 0 {
+		q.Set("size", strconv.Itoa(m.Size))
+	}
+
+	if m.StartIndex > 0 {
+		q.Set("start_index", strconv.Itoa(m.StartIndex))
+	}
+
+	return q.Encode()
+}
+
 // helper function to format search params into a search path
 func (m *SearchEndpoint) path(params SearchParams, extra ...string) string {
 	p := "/search"
@@ -57,9 +274,14 @@ func (m *SearchEndpoint) path(params SearchParams, extra ...string) string {
 // Search companies, officers and disqualified officers
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search/search-all
 func (m *SearchEndpoint) All(params SearchParams) (*Search, error) {
+	return m.AllContext(context.Background(), params)
+}
+
+// AllContext is the same as All but uses the provided context
+func (m *SearchEndpoint) AllContext(ctx context.Context, params SearchParams) (*Search, error) {
 	s := &Search{}
 
-	if err := m.Client.GetJSON(m.path(params), s); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path(params), s); err != nil {
 		return nil, err
 	}
 
@@ -69,9 +291,14 @@ func (m *SearchEndpoint) All(params SearchParams) (*Search, error) {
 // Search company information
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search/search-companies
 func (m *SearchEndpoint) Companies(params SearchParams) (*CompanySearch, error) {
+	return m.CompaniesContext(context.Background(), params)
+}
+
+// CompaniesContext is the same as Companies but uses the provided context
+func (m *SearchEndpoint) CompaniesContext(ctx context.Context, params SearchParams) (*CompanySearch, error) {
 	s := &CompanySearch{}
 
-	if err := m.Client.GetJSON(m.path(params, "companies"), s); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path(params, "companies"), s); err != nil {
 		return nil, err
 	}
 
@@ -81,9 +308,14 @@ func (m *SearchEndpoint) Companies(params SearchParams) (*CompanySearch, error)
 // Search for officer information
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search/search-officers
 func (m *SearchEndpoint) Officers(params SearchParams) (*OfficerSearch, error) {
+	return m.OfficersContext(context.Background(), params)
+}
+
+// OfficersContext is the same as Officers but uses the provided context
+func (m *SearchEndpoint) OfficersContext(ctx context.Context, params SearchParams) (*OfficerSearch, error) {
 	s := &OfficerSearch{}
 
-	if err := m.Client.GetJSON(m.path(params, "officers"), s); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path(params, "officers"), s); err != nil {
 		return nil, err
 	}
 
@@ -93,9 +325,174 @@ func (m *SearchEndpoint) Officers(params SearchParams) (*OfficerSearch, error) {
 // Search for disqualified officer information
 // https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search/search-disqualified-officers
 func (m *SearchEndpoint) DisqualifiedOfficers(params SearchParams) (*DisqualifiedOfficerSearch, error) {
+	return m.DisqualifiedOfficersContext(context.Background(), params)
+}
+
+// DisqualifiedOfficersContext is the same as DisqualifiedOfficers but uses the provided context
+func (m *SearchEndpoint) DisqualifiedOfficersContext(ctx context.Context, params SearchParams) (*DisqualifiedOfficerSearch, error) {
 	s := &DisqualifiedOfficerSearch{}
 
-	if err := m.Client.GetJSON(m.path(params, "disqualified-officers"), s); err != nil {
+	if err := m.Client.GetJSONContext(ctx, m.path(params, "disqualified-officers"), s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// AllIter returns an iterator over every result of All, fetching further
+// pages as required starting from params.StartIndex
+func (m *SearchEndpoint) AllIter(params SearchParams) *SearchIterator {
+	return m.AllIterContext(context.Background(), params)
+}
+
+// AllIterContext is the same as AllIter but uses the provided context
+func (m *SearchEndpoint) AllIterContext(ctx context.Context, params SearchParams) *SearchIterator {
+	it := &SearchIterator{}
+
+	it.pager = newPager(ctx, params.StartIndex, func(ctx context.Context, start int) (int, int, error) {
+		params.StartIndex = start
+
+		s, err := m.AllContext(ctx, params)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		it.items = s.Items
+
+		return len(s.Items), s.TotalResults, nil
+	})
+
+	return it
+}
+
+// CompaniesIter returns an iterator over every result of Companies, fetching
+// further pages as required starting from params.StartIndex
+func (m *SearchEndpoint) CompaniesIter(params SearchParams) *CompanySearchIterator {
+	return m.CompaniesIterContext(context.Background(), params)
+}
+
+// CompaniesIterContext is the same as CompaniesIter but uses the provided context
+func (m *SearchEndpoint) CompaniesIterContext(ctx context.Context, params SearchParams) *CompanySearchIterator {
+	it := &CompanySearchIterator{}
+
+	it.pager = newPager(ctx, params.StartIndex, func(ctx context.Context, start int) (int, int, error) {
+		params.StartIndex = start
+
+		s, err := m.CompaniesContext(ctx, params)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		it.items = s.Items
+
+		return len(s.Items), s.TotalResults, nil
+	})
+
+	return it
+}
+
+// OfficersIter returns an iterator over every result of Officers, fetching
+// further pages as required starting from params.StartIndex
+func (m *SearchEndpoint) OfficersIter(params SearchParams) *OfficerSearchIterator {
+	return m.OfficersIterContext(context.Background(), params)
+}
+
+// OfficersIterContext is the same as OfficersIter but uses the provided context
+func (m *SearchEndpoint) OfficersIterContext(ctx context.Context, params SearchParams) *OfficerSearchIterator {
+	it := &OfficerSearchIterator{}
+
+	it.pager = newPager(ctx, params.StartIndex, func(ctx context.Context, start int) (int, int, error) {
+		params.StartIndex = start
+
+		s, err := m.OfficersContext(ctx, params)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		it.items = s.Items
+
+		return len(s.Items), s.TotalResults, nil
+	})
+
+	return it
+}
+
+// DisqualifiedOfficersIter returns an iterator over every result of
+// DisqualifiedOfficers, fetching further pages as required starting from
+// params.StartIndex
+func (m *SearchEndpoint) DisqualifiedOfficersIter(params SearchParams) *DisqualifiedOfficerSearchIterator {
+	return m.DisqualifiedOfficersIterContext(context.Background(), params)
+}
+
+// DisqualifiedOfficersIterContext is the same as DisqualifiedOfficersIter but uses the provided context
+func (m *SearchEndpoint) DisqualifiedOfficersIterContext(ctx context.Context, params SearchParams) *DisqualifiedOfficerSearchIterator {
+	it := &DisqualifiedOfficerSearchIterator{}
+
+	it.pager = newPager(ctx, params.StartIndex, func(ctx context.Context, start int) (int, int, error) {
+		params.StartIndex = start
+
+		s, err := m.DisqualifiedOfficersContext(ctx, params)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		it.items = s.Items
+
+		return len(s.Items), s.TotalResults, nil
+	})
+
+	return it
+}
+
+// Search for companies using filters
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search/advanced-company-search
+func (m *SearchEndpoint) AdvancedCompanies(params AdvancedSearchParams) (*AdvancedCompanySearch, error) {
+	return m.AdvancedCompaniesContext(context.Background(), params)
+}
+
+// AdvancedCompaniesContext is the same as AdvancedCompanies but uses the provided context
+func (m *SearchEndpoint) AdvancedCompaniesContext(ctx context.Context, params AdvancedSearchParams) (*AdvancedCompanySearch, error) {
+	if err := params.Validate(); err != nil {
+		return nil, err
+	}
+
+	s := &AdvancedCompanySearch{}
+
+	if err := m.Client.GetJSONContext(ctx, withQuery("/advanced-search/companies", params.Encode()), s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Search for companies with names alphabetically close to the query
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search/alphabetical-company-search
+func (m *SearchEndpoint) AlphabeticalCompanies(params AlphabeticalSearchParams) (*AlphabeticalCompanySearch, error) {
+	return m.AlphabeticalCompaniesContext(context.Background(), params)
+}
+
+// AlphabeticalCompaniesContext is the same as AlphabeticalCompanies but uses the provided context
+func (m *SearchEndpoint) AlphabeticalCompaniesContext(ctx context.Context, params AlphabeticalSearchParams) (*AlphabeticalCompanySearch, error) {
+	s := &AlphabeticalCompanySearch{}
+
+	if err := m.Client.GetJSONContext(ctx, withQuery("/alphabetical-search/companies", params.Encode()), s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Search for dissolved companies by current or previous name
+// https://developer-specs.company-information.service.gov.uk/companies-house-public-data-api/reference/search/dissolved-company-search
+func (m *SearchEndpoint) DissolvedCompanies(params DissolvedSearchParams) (*DissolvedCompanySearch, error) {
+	return m.DissolvedCompaniesContext(context.Background(), params)
+}
+
+// DissolvedCompaniesContext is the same as DissolvedCompanies but uses the provided context
+func (m *SearchEndpoint) DissolvedCompaniesContext(ctx context.Context, params DissolvedSearchParams) (*DissolvedCompanySearch, error) {
+	s := &DissolvedCompanySearch{}
+
+	if err := m.Client.GetJSONContext(ctx, withQuery("/dissolved-search/companies", params.Encode()), s); err != nil {
 		return nil, err
 	}
 
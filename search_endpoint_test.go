@@ -1,8 +1,11 @@
 package comphouse
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -28,6 +31,220 @@ func TestSearchParamsEncode(t *testing.T) {
 	}
 }
 
+func TestAdvancedSearchParamsEncode(t *testing.T) {
+	type test struct {
+		inp AdvancedSearchParams
+		exp string
+	}
+
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			panic(err)
+		}
+
+		return d
+	}
+
+	tests := []test{
+		{AdvancedSearchParams{}, ""},
+		{AdvancedSearchParams{CompanyNameIncludes: "tesco & co"}, "company_name_includes=tesco+%26+co"},
+		{
+			AdvancedSearchParams{CompanyStatus: []CompanyStatus{CompanyStatusActive, CompanyStatusDissolved}, CompanyType: []CompanyType{CompanyTypeLtd}},
+			"company_status=active&company_status=dissolved&company_type=ltd",
+		},
+		{
+			AdvancedSearchParams{IncorporatedFrom: date("2020-01-01"), IncorporatedTo: date("2020-12-31"), Location: "Cardiff"},
+			"incorporated_from=2020-01-01&incorporated_to=2020-12-31&location=Cardiff",
+		},
+		{
+			AdvancedSearchParams{SicCodes: []SIC{"62012", "62020"}, CompanySubtype: CompanySubtypeCommunityInterestCompany, Size: 100, StartIndex: 200},
+			"company_subtype=community-interest-company&sic_codes=62012&sic_codes=62020&size=100&start_index=200",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(test.exp, test.inp.Encode())
+		})
+	}
+}
+
+func TestAdvancedSearchParamsValidate(t *testing.T) {
+	type test struct {
+		name  string
+		inp   AdvancedSearchParams
+		valid bool
+	}
+
+	jan := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	dec := time.Date(2020, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []test{
+		{"empty", AdvancedSearchParams{}, true},
+		{"dates in order", AdvancedSearchParams{IncorporatedFrom: jan, IncorporatedTo: dec, DissolvedFrom: jan, DissolvedTo: dec}, true},
+		{"dissolved status", AdvancedSearchParams{CompanyStatus: []CompanyStatus{CompanyStatusDissolved}, DissolvedFrom: jan}, true},
+		{"known sic codes", AdvancedSearchParams{SicCodes: []SIC{"62012"}}, true},
+		{"size too large", AdvancedSearchParams{Size: MaxAdvancedSearchSize + 1}, false},
+		{"negative size", AdvancedSearchParams{Size: -1}, false},
+		{"negative start index", AdvancedSearchParams{StartIndex: -1}, false},
+		{"same includes and excludes", AdvancedSearchParams{CompanyNameIncludes: "Tesco", CompanyNameExcludes: "tesco"}, false},
+		{"incorporated dates reversed", AdvancedSearchParams{IncorporatedFrom: dec, IncorporatedTo: jan}, false},
+		{"dissolved dates reversed", AdvancedSearchParams{DissolvedFrom: dec, DissolvedTo: jan}, false},
+		{"dissolved dates with active status", AdvancedSearchParams{CompanyStatus: []CompanyStatus{CompanyStatusActive}, DissolvedTo: dec}, false},
+		{"incorporated after dissolved", AdvancedSearchParams{IncorporatedFrom: dec, DissolvedTo: jan}, false},
+		{"unknown sic code", AdvancedSearchParams{SicCodes: []SIC{"00000"}}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			err := test.inp.Validate()
+
+			if test.valid {
+				assert.NoError(err)
+			} else {
+				assert.ErrorIs(err, ErrInvalidSearchParams)
+			}
+		})
+	}
+}
+
+func TestSearchEndpointAdvancedCompanies(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{"hits": 1, "items": [{"company_name": "Company Name", "company_number": "00000001", "sic_codes": ["62012"]}]}`)
+	})
+
+	defer ts.Close()
+
+	s, err := c.Search().AdvancedCompanies(AdvancedSearchParams{Location: "Cardiff"})
+
+	if assert.NoError(err) {
+		assert.Equal("/advanced-search/companies?location=Cardiff", uri)
+		assert.Equal(1, s.Hits)
+		assert.Equal("00000001", s.Items[0].CompanyNumber.String())
+		assert.Equal([]SIC{"62012"}, s.Items[0].SicCodes)
+	}
+}
+
+func TestSearchEndpointAdvancedCompaniesValidatesParams(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+	})
+
+	defer ts.Close()
+
+	s, err := c.Search().AdvancedCompanies(AdvancedSearchParams{Size: -1})
+
+	assert.Nil(s)
+	assert.ErrorIs(err, ErrInvalidSearchParams)
+	assert.Zero(requests)
+}
+
+func TestAlphabeticalSearchParamsEncode(t *testing.T) {
+	type test struct {
+		inp AlphabeticalSearchParams
+		exp string
+	}
+
+	tests := []test{
+		{AlphabeticalSearchParams{Query: "test@test"}, "q=test%40test"},
+		{AlphabeticalSearchParams{Query: "tesco", SearchAbove: "TESCO:00445790", Size: 10}, "q=tesco&search_above=TESCO%3A00445790&size=10"},
+		{AlphabeticalSearchParams{Query: "tesco", SearchBelow: "TESCO:00445790"}, "q=tesco&search_below=TESCO%3A00445790"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(test.exp, test.inp.Encode())
+		})
+	}
+}
+
+func TestDissolvedSearchParamsEncode(t *testing.T) {
+	type test struct {
+		inp DissolvedSearchParams
+		exp string
+	}
+
+	tests := []test{
+		{DissolvedSearchParams{Query: "tesco"}, "q=tesco&search_type=alphabetical"},
+		{DissolvedSearchParams{Query: "tesco", SearchType: DissolvedSearchBestMatch, Size: 20, StartIndex: 40}, "q=tesco&search_type=best-match&size=20&start_index=40"},
+		{DissolvedSearchParams{Query: "tesco", SearchType: DissolvedSearchPreviousNameDissolved}, "q=tesco&search_type=previous-name-dissolved"},
+		{DissolvedSearchParams{Query: "tesco", SearchAbove: "TESCO:00445790"}, "q=tesco&search_above=TESCO%3A00445790&search_type=alphabetical"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exp, func(t *testing.T) {
+			assert := assert.New(t)
+			assert.Equal(test.exp, test.inp.Encode())
+		})
+	}
+}
+
+func TestSearchEndpointAlphabeticalCompanies(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{"items": [{"company_name": "TESCO LIMITED", "ordered_alpha_key_with_id": "TESCO:00445790"}], "top_hit": {"company_name": "TESCO LIMITED"}}`)
+	})
+
+	defer ts.Close()
+
+	s, err := c.Search().AlphabeticalCompanies(AlphabeticalSearchParams{Query: "tesco"})
+
+	if assert.NoError(err) {
+		assert.Equal("/alphabetical-search/companies?q=tesco", uri)
+		assert.Equal("TESCO:00445790", s.Items[0].OrderedAlphaKeyWithID)
+		assert.Equal("TESCO LIMITED", s.TopHit.CompanyName)
+	}
+}
+
+func TestSearchEndpointDissolvedCompanies(t *testing.T) {
+	assert := assert.New(t)
+
+	var uri string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		uri = r.URL.RequestURI()
+		fmt.Fprint(w, `{
+			"hits": 1,
+			"items": [{
+				"company_name": "OLD TESCO LIMITED",
+				"date_of_cessation": "2010-01-01",
+				"previous_company_names": [{"name": "TESCO LIMITED", "ceased_on": "2005-01-01"}],
+				"matched_previous_company_name": {"name": "TESCO LIMITED"}
+			}]
+		}`)
+	})
+
+	defer ts.Close()
+
+	s, err := c.Search().DissolvedCompanies(DissolvedSearchParams{Query: "tesco", SearchType: DissolvedSearchPreviousNameDissolved})
+
+	if assert.NoError(err) {
+		assert.Equal("/dissolved-search/companies?q=tesco&search_type=previous-name-dissolved", uri)
+		assert.Equal(1, s.Hits)
+		assert.Equal("2010-01-01", s.Items[0].DateOfCessation)
+		assert.Equal("TESCO LIMITED", s.Items[0].PreviousCompanyNames[0].Name)
+		assert.Equal("TESCO LIMITED", s.Items[0].MatchedPreviousCompanyName.Name)
+	}
+}
+
 func checkSearchEndpointHandlesError(t *testing.T, f func(*SearchEndpoint) error) {
 	assert := assert.New(t)
 
@@ -76,6 +293,27 @@ func TestSearchEndpointHandlesErrors(t *testing.T) {
 				return err
 			},
 		},
+		{
+			"SearchEndpoint.AdvancedCompanies",
+			func(s *SearchEndpoint) error {
+				_, err := s.AdvancedCompanies(AdvancedSearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.AlphabeticalCompanies",
+			func(s *SearchEndpoint) error {
+				_, err := s.AlphabeticalCompanies(AlphabeticalSearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.DissolvedCompanies",
+			func(s *SearchEndpoint) error {
+				_, err := s.DissolvedCompanies(DissolvedSearchParams{})
+				return err
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -116,6 +354,24 @@ func TestSearchEndpointDecodesResponses(t *testing.T) {
 				return s.DisqualifiedOfficers(SearchParams{})
 			},
 		},
+		{
+			"SearchEndpoint.AdvancedCompanies",
+			func(s *SearchEndpoint) (interface{}, error) {
+				return s.AdvancedCompanies(AdvancedSearchParams{})
+			},
+		},
+		{
+			"SearchEndpoint.AlphabeticalCompanies",
+			func(s *SearchEndpoint) (interface{}, error) {
+				return s.AlphabeticalCompanies(AlphabeticalSearchParams{})
+			},
+		},
+		{
+			"SearchEndpoint.DissolvedCompanies",
+			func(s *SearchEndpoint) (interface{}, error) {
+				return s.DissolvedCompanies(DissolvedSearchParams{})
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -133,3 +389,79 @@ func TestSearchEndpointDecodesResponses(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchEndpointRespectsContext(t *testing.T) {
+	type test struct {
+		name string
+		f    func(context.Context, *SearchEndpoint) error
+	}
+
+	tests := []test{
+		{
+			"SearchEndpoint.AllContext",
+			func(ctx context.Context, s *SearchEndpoint) error {
+				_, err := s.AllContext(ctx, SearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.CompaniesContext",
+			func(ctx context.Context, s *SearchEndpoint) error {
+				_, err := s.CompaniesContext(ctx, SearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.OfficersContext",
+			func(ctx context.Context, s *SearchEndpoint) error {
+				_, err := s.OfficersContext(ctx, SearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.DisqualifiedOfficersContext",
+			func(ctx context.Context, s *SearchEndpoint) error {
+				_, err := s.DisqualifiedOfficersContext(ctx, SearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.AdvancedCompaniesContext",
+			func(ctx context.Context, s *SearchEndpoint) error {
+				_, err := s.AdvancedCompaniesContext(ctx, AdvancedSearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.AlphabeticalCompaniesContext",
+			func(ctx context.Context, s *SearchEndpoint) error {
+				_, err := s.AlphabeticalCompaniesContext(ctx, AlphabeticalSearchParams{})
+				return err
+			},
+		},
+		{
+			"SearchEndpoint.DissolvedCompaniesContext",
+			func(ctx context.Context, s *SearchEndpoint) error {
+				_, err := s.DissolvedCompaniesContext(ctx, DissolvedSearchParams{})
+				return err
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			ts, c := createTestServerWithResponse(map[string]string{"company_name": "Company Name"})
+
+			defer ts.Close()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := test.f(ctx, c.Search())
+
+			assert.Same(context.Canceled, err)
+		})
+	}
+}
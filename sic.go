@@ -12,6 +12,12 @@ func (m SIC) Description() string {
 	return "Unknown"
 }
 
+// Valid reports whether the SIC code is known
+func (m SIC) Valid() bool {
+	_, ok := _sics[string(m)]
+	return ok
+}
+
 // https://github.com/companieshouse/api-enumerations/blob/c85c6639b8e99506571a31e601e29220dcfc4d83/constants.yml
 var _sics = map[string]string{
 	"0111":  "Grow cereals & other crops",
@@ -17,3 +17,10 @@ func TestSICDescriptionNotFound(t *testing.T) {
 
 	assert.Equal("Unknown", SIC("0000").Description())
 }
+
+func TestSICValid(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(SIC("62012").Valid())
+	assert.False(SIC("0000").Valid())
+}
@@ -0,0 +1,241 @@
+package comphouse
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default values used when creating a new StreamEndpoint
+const (
+	DefaultStreamHost             = "stream.companieshouse.gov.uk"
+	DefaultStreamHeartbeatTimeout = time.Minute
+	DefaultStreamReconnectDelay   = time.Second
+	DefaultStreamMaxReconnectWait = time.Minute
+)
+
+// Stream is the name of one of the Companies House Streaming API's streams
+type Stream string
+
+// Streams available from the Companies House Streaming API
+const (
+	StreamCompanies                               Stream = "companies"
+	StreamFilings                                 Stream = "filings"
+	StreamOfficers                                Stream = "officers"
+	StreamPersonsWithSignificantControl           Stream = "persons-with-significant-control"
+	StreamPersonsWithSignificantControlStatements Stream = "persons-with-significant-control-statements"
+	StreamCharges                                 Stream = "charges"
+	StreamInsolvencyCases                         Stream = "insolvency-cases"
+	StreamDisqualifiedOfficers                    Stream = "disqualified-officers"
+	StreamCompanyExemptions                       Stream = "company-exemptions"
+)
+
+// StreamEndpoint is a struct that can be used to consume one of the streams
+// provided by the Companies House Streaming API. Requests are sent to the
+// Client's StreamHost, or its Host if StreamHost is empty. Streaming API keys
+// are separate from REST API keys, so the Client should use a stream key
+// https://developer-specs.company-information.service.gov.uk/streaming-api/reference
+//
+// A StreamEndpoint is not safe for concurrent use as Timepoint is updated as
+// events are received
+type StreamEndpoint struct {
+	Client *Client
+	Stream Stream
+
+	// Timepoint is the timepoint the stream is requested from. Zero requests
+	// only new events. It is advanced past each event as it is handled so a
+	// dropped connection resumes where it left off
+	Timepoint int64
+
+	// HeartbeatTimeout is how long to wait for an event or heartbeat before
+	// assuming the connection has stalled and reconnecting
+	HeartbeatTimeout time.Duration
+
+	// ReconnectDelay is the initial delay before reconnecting after the
+	// connection is lost. It doubles on each consecutive failure up to
+	// DefaultStreamMaxReconnectWait
+	ReconnectDelay time.Duration
+}
+
+// wraps errors that should stop Listen rather than cause a reconnect
+type streamFatalError struct {
+	err error
+}
+
+func (m *streamFatalError) Error() string {
+	return m.err.Error()
+}
+
+// helper method to format a path for a stream
+func (m *StreamEndpoint) path() string {
+	p := "/" + string(m.Stream)
+
+	if m.Timepoint > 0 {
+		p += "?timepoint=" + strconv.FormatInt(m.Timepoint, 10)
+	}
+
+	return p
+}
+
+// helper method to format the URL for a stream
+func (m *StreamEndpoint) url() string {
+	if m.Client.StreamHost == "" {
+		return m.path()
+	}
+
+	return m.Client.Protocol + "://" + m.Client.StreamHost + m.path()
+}
+
+// Listen connects to the stream and calls handler with each event received
+// until the context is done or handler returns an error, which is returned
+// as is. Heartbeats are consumed silently and the stream is reconnected from
+// the last handled timepoint if the connection drops or stalls. Responses
+// with a 4xx status other than 429 and events that can't be decoded are not
+// retried and their errors are returned
+func (m *StreamEndpoint) Listen(ctx context.Context, handler func(StreamEvent) error) error {
+	backoff := &RetryPolicy{
+		MinBackoff: m.ReconnectDelay,
+		MaxBackoff: DefaultStreamMaxReconnectWait,
+	}
+
+	if backoff.MinBackoff <= 0 {
+		backoff.MinBackoff = DefaultStreamReconnectDelay
+	}
+
+	for failures := 0; ; {
+		received, err := m.listen(ctx, handler)
+
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var fatalErr *streamFatalError
+		if errors.As(err, &fatalErr) {
+			return fatalErr.err
+		}
+
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusTooManyRequests {
+			return err
+		}
+
+		if received {
+			failures = 0
+		}
+
+		failures++
+
+		delay := backoff.Backoff(failures)
+		if apiErr != nil {
+			if d, ok := retryAfter(apiErr.Header, time.Now()); ok {
+				delay = d
+			}
+		}
+
+		if err := sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// Events connects to the stream and delivers events over the returned channel
+// until the context is done or an unrecoverable error occurs. The error is
+// sent on the error channel after the event channel is closed
+func (m *StreamEndpoint) Events(ctx context.Context) (<-chan StreamEvent, <-chan error) {
+	events := make(chan StreamEvent)
+	errs := make(chan error, 1)
+
+	go func() {
+		err := m.Listen(ctx, func(e StreamEvent) error {
+			select {
+			case events <- e:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+
+		close(events)
+
+		errs <- err
+		close(errs)
+	}()
+
+	return events, errs
+}
+
+// listen consumes a single connection to the stream. It reports whether any
+// events were handled so Listen can reset its backoff
+func (m *StreamEndpoint) listen(ctx context.Context, handler func(StreamEvent) error) (bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := m.Client.NewRequestContext(ctx, http.MethodGet, m.url(), nil)
+	if err != nil {
+		return false, &streamFatalError{err}
+	}
+
+	// streams are long lived so the client's timeout can't be used. The
+	// heartbeat timer is armed before the request is sent so a server that
+	// never responds is treated the same as one that stops sending events
+	hc := *m.Client.HTTP
+	hc.Timeout = 0
+
+	timeout := m.HeartbeatTimeout
+	if timeout <= 0 {
+		timeout = DefaultStreamHeartbeatTimeout
+	}
+
+	timer := time.AfterFunc(timeout, cancel)
+	defer timer.Stop()
+
+	resp, err := m.Client.chain(RoundTripperFunc(hc.Do)).RoundTrip(req)
+	if err != nil {
+		return false, err
+	}
+
+	if err := statusCodeToError(resp.StatusCode); err != nil {
+		return false, newAPIError(resp, err)
+	}
+
+	defer resp.Body.Close()
+
+	timer.Reset(timeout)
+
+	var (
+		r        = bufio.NewReader(resp.Body)
+		received bool
+	)
+
+	for {
+		line, err := r.ReadBytes('\n')
+		if err != nil {
+			return received, err
+		}
+
+		timer.Stop()
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var e StreamEvent
+
+			if err := json.Unmarshal(line, &e); err != nil {
+				return received, &streamFatalError{fmt.Errorf("decoding stream event: %w", err)}
+			}
+
+			if err := handler(e); err != nil {
+				return received, &streamFatalError{err}
+			}
+
+			m.Timepoint = e.Event.Timepoint + 1
+			received = true
+		}
+
+		timer.Reset(timeout)
+	}
+}
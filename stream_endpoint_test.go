@@ -0,0 +1,252 @@
+package comphouse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func streamEventJSON(timepoint int64, kind string) string {
+	return fmt.Sprintf(
+		`{"resource_kind": %q, "resource_uri": "/company/00000001", "resource_id": "00000001", "data": {"company_name": "Company %d"}, "event": {"timepoint": %d, "published_at": "2021-06-01T12:00:00", "type": "changed"}}`,
+		kind, timepoint, timepoint,
+	)
+}
+
+func TestStreamEndpointListen(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu         sync.Mutex
+		timepoints []string
+	)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		timepoints = append(timepoints, r.URL.Query().Get("timepoint"))
+		connection := len(timepoints)
+		mu.Unlock()
+
+		assert.Equal("/companies", r.URL.Path)
+
+		// the first connection sends two events separated by a heartbeat
+		// before dropping, the second resumes from the next timepoint
+		if connection == 1 {
+			fmt.Fprintln(w, streamEventJSON(10, "company-profile"))
+			fmt.Fprintln(w)
+			fmt.Fprintln(w, streamEventJSON(11, "company-profile"))
+			return
+		}
+
+		fmt.Fprintln(w, streamEventJSON(12, "company-profile"))
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	})
+
+	defer ts.Close()
+
+	s := c.Stream(StreamCompanies)
+	s.ReconnectDelay = time.Millisecond
+
+	var names []string
+
+	stop := errors.New("stop")
+
+	err := s.Listen(context.Background(), func(e StreamEvent) error {
+		profile, err := e.CompanyProfile()
+		assert.NoError(err)
+
+		names = append(names, profile.CompanyName)
+
+		if e.Event.Timepoint == 12 {
+			return stop
+		}
+
+		return nil
+	})
+
+	assert.Same(stop, err)
+	assert.Equal([]string{"Company 10", "Company 11", "Company 12"}, names)
+	assert.Equal([]string{"", "12"}, timepoints)
+	assert.Equal(int64(12), s.Timepoint)
+}
+
+func TestStreamEndpointReconnectsWhenStalled(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu          sync.Mutex
+		connections int
+	)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections++
+		connection := connections
+		mu.Unlock()
+
+		if connection == 2 {
+			fmt.Fprintln(w, streamEventJSON(1, "company-profile"))
+			return
+		}
+
+		w.WriteHeader(200)
+		w.(http.Flusher).Flush()
+
+		<-r.Context().Done()
+	})
+
+	defer ts.Close()
+
+	s := c.Stream(StreamCompanies)
+	s.HeartbeatTimeout = time.Millisecond * 50
+	s.ReconnectDelay = time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, errs := s.Events(ctx)
+
+	e := <-events
+	assert.Equal(int64(1), e.Event.Timepoint)
+	assert.Equal("company-profile", e.ResourceKind)
+
+	cancel()
+
+	for range events {
+	}
+
+	assert.ErrorIs(<-errs, context.Canceled)
+	assert.GreaterOrEqual(connections, 2)
+}
+
+func TestStreamEndpointReconnectsWhenStalledBeforeHeaders(t *testing.T) {
+	assert := assert.New(t)
+
+	var (
+		mu          sync.Mutex
+		connections int
+	)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections++
+		connection := connections
+		mu.Unlock()
+
+		if connection == 2 {
+			fmt.Fprintln(w, streamEventJSON(1, "company-profile"))
+			w.(http.Flusher).Flush()
+
+			<-r.Context().Done()
+			return
+		}
+
+		// accept the connection but never send the response headers
+		<-r.Context().Done()
+	})
+
+	defer ts.Close()
+
+	s := c.Stream(StreamCompanies)
+	s.HeartbeatTimeout = time.Millisecond * 100
+	s.ReconnectDelay = time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+	defer cancel()
+
+	stop := errors.New("stop")
+
+	err := s.Listen(ctx, func(e StreamEvent) error {
+		assert.Equal(int64(1), e.Event.Timepoint)
+		return stop
+	})
+
+	assert.Same(stop, err)
+	assert.Equal(2, connections)
+}
+
+func TestStreamEndpointDoesNotRetryClientErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+		w.WriteHeader(401)
+	})
+
+	defer ts.Close()
+
+	err := c.Stream(StreamFilings).Listen(context.Background(), func(StreamEvent) error {
+		return nil
+	})
+
+	assert.ErrorIs(err, ErrUnauthorized)
+	assert.Equal(1, requests)
+}
+
+func TestStreamEndpointRetriesServerErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	var requests int
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		requests++
+
+		if requests < 3 {
+			w.WriteHeader(503)
+			return
+		}
+
+		fmt.Fprintln(w, streamEventJSON(1, "filing-history"))
+	})
+
+	defer ts.Close()
+
+	s := c.Stream(StreamFilings)
+	s.ReconnectDelay = time.Millisecond
+
+	err := s.Listen(context.Background(), func(StreamEvent) error {
+		return context.Canceled
+	})
+
+	assert.Same(context.Canceled, err)
+	assert.Equal(3, requests)
+}
+
+func TestStreamEndpointInvalidEvent(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "{not json")
+	})
+
+	defer ts.Close()
+
+	err := c.Stream(StreamCharges).Listen(context.Background(), func(StreamEvent) error {
+		return nil
+	})
+
+	assert.Error(err)
+	assert.Contains(err.Error(), "decoding stream event")
+}
+
+func TestStreamEndpointPath(t *testing.T) {
+	assert := assert.New(t)
+
+	s := NewClient("localhost", nil).Stream(StreamInsolvencyCases)
+
+	assert.Equal("/insolvency-cases", s.path())
+
+	s.Timepoint = 1234
+
+	assert.Equal("/insolvency-cases?timepoint=1234", s.path())
+}
@@ -0,0 +1,202 @@
+package comphouse
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Span attribute keys set by the Client
+const (
+	SpanAttrEndpoint      = "comphouse.endpoint"
+	SpanAttrCompanyNumber = "comphouse.company_number"
+	SpanAttrAttempts      = "comphouse.attempts"
+	SpanAttrCache         = "comphouse.cache"
+	SpanAttrMethod        = "http.method"
+	SpanAttrStatusCode    = "http.status_code"
+)
+
+// Cache outcomes recorded on spans under SpanAttrCache
+const (
+	SpanCacheHit         = "hit"
+	SpanCacheRevalidated = "revalidated"
+	SpanCacheMiss        = "miss"
+)
+
+// Tracer is implemented by tracing backends. The Client starts a span for
+// every API call made through Do, Get and GetJSON and the endpoint methods
+// built on top of them
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is a single traced API call. Implementations must be safe for
+// concurrent use
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+
+	// TraceParent returns the W3C traceparent header value identifying the
+	// span, or an empty string if it shouldn't be propagated
+	TraceParent() string
+
+	End()
+}
+
+type spanKey struct{}
+
+// ContextWithSpan returns a copy of ctx holding span. Tracers should use it
+// so the Client can propagate the span and add attributes to it
+func ContextWithSpan(ctx context.Context, span Span) context.Context {
+	return context.WithValue(ctx, spanKey{}, span)
+}
+
+// SpanFromContext returns the span held by ctx, or a no-op span if there
+// isn't one
+func SpanFromContext(ctx context.Context) Span {
+	if span, ok := ctx.Value(spanKey{}).(Span); ok {
+		return span
+	}
+
+	return noopSpan{}
+}
+
+// NoopTracer is a Tracer that doesn't record anything. It is used when a
+// Client doesn't have a Tracer
+type NoopTracer struct{}
+
+// Start returns ctx unchanged and a span that does nothing
+func (NoopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, interface{}) {}
+func (noopSpan) RecordError(error)                {}
+func (noopSpan) TraceParent() string              { return "" }
+func (noopSpan) End()                             {}
+
+// startSpan starts a span for a call to path, naming it after the endpoint
+// the path belongs to
+func (m *Client) startSpan(ctx context.Context, method, path string) (context.Context, Span) {
+	tracer := m.Tracer
+	if tracer == nil {
+		tracer = NoopTracer{}
+	}
+
+	var p string
+	if u, err := url.Parse(m.URL(path)); err == nil {
+		p = u.Path
+	}
+
+	endpoint := EndpointTemplate(p)
+	if endpoint == "" {
+		endpoint = p
+	}
+
+	ctx, span := tracer.Start(ctx, method+" "+endpoint)
+
+	span.SetAttribute(SpanAttrEndpoint, endpoint)
+	span.SetAttribute(SpanAttrMethod, method)
+
+	if companyNo := companyNumberFromPath(p); companyNo != "" {
+		span.SetAttribute(SpanAttrCompanyNumber, companyNo)
+	}
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// RecordingTracer is a Tracer that keeps every span in memory. It is intended
+// for use in tests
+type RecordingTracer struct {
+	mu    sync.Mutex
+	spans []*RecordedSpan
+}
+
+// Start starts a new RecordedSpan. Spans started from a context holding a
+// RecordedSpan share its trace ID
+func (m *RecordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	span := &RecordedSpan{
+		Name:       name,
+		SpanID:     randomHex(8),
+		StartTime:  time.Now(),
+		Attributes: map[string]interface{}{},
+	}
+
+	if parent, ok := ctx.Value(spanKey{}).(*RecordedSpan); ok {
+		span.TraceID = parent.TraceID
+		span.ParentID = parent.SpanID
+	} else {
+		span.TraceID = randomHex(16)
+	}
+
+	m.mu.Lock()
+	m.spans = append(m.spans, span)
+	m.mu.Unlock()
+
+	return ContextWithSpan(ctx, span), span
+}
+
+// Spans returns the spans started so far
+func (m *RecordingTracer) Spans() []*RecordedSpan {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*RecordedSpan(nil), m.spans...)
+}
+
+// RecordedSpan is a span recorded by a RecordingTracer. Its fields shouldn't
+// be accessed until the span has ended
+type RecordedSpan struct {
+	mu sync.Mutex
+
+	Name       string
+	TraceID    string
+	SpanID     string
+	ParentID   string
+	StartTime  time.Time
+	EndTime    time.Time
+	Attributes map[string]interface{}
+	Errors     []error
+}
+
+// SetAttribute sets an attribute on the span
+func (m *RecordedSpan) SetAttribute(key string, value interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Attributes[key] = value
+}
+
+// RecordError records an error on the span
+func (m *RecordedSpan) RecordError(err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.Errors = append(m.Errors, err)
+}
+
+// TraceParent returns the W3C traceparent header value for the span
+func (m *RecordedSpan) TraceParent() string {
+	return "00-" + m.TraceID + "-" + m.SpanID + "-01"
+}
+
+// End marks the span as finished
+func (m *RecordedSpan) End() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.EndTime = time.Now()
+}
+
+// randomHex returns n random bytes encoded as hex
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,175 @@
+package comphouse
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientTracesCalls(t *testing.T) {
+	assert := assert.New(t)
+
+	var traceparents []string
+
+	requests := 0
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		traceparents = append(traceparents, r.Header.Get("traceparent"))
+
+		if requests++; requests == 1 {
+			w.WriteHeader(503)
+			return
+		}
+
+		w.Write([]byte(`{"company_name": "Test Company"}`))
+	})
+
+	defer ts.Close()
+
+	tracer := &RecordingTracer{}
+
+	c.Tracer = tracer
+	c.Retry = &RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	_, err := c.Company(EnglishCompanyNo(1)).Profile()
+	assert.NoError(err)
+
+	spans := tracer.Spans()
+	assert.Len(spans, 1)
+
+	span := spans[0]
+
+	assert.Equal("GET /company/{company_number}", span.Name)
+	assert.Equal("/company/{company_number}", span.Attributes[SpanAttrEndpoint])
+	assert.Equal("00000001", span.Attributes[SpanAttrCompanyNumber])
+	assert.Equal("GET", span.Attributes[SpanAttrMethod])
+	assert.Equal(200, span.Attributes[SpanAttrStatusCode])
+	assert.Equal(2, span.Attributes[SpanAttrAttempts])
+	assert.NotContains(span.Attributes, SpanAttrCache)
+	assert.Empty(span.Errors)
+	assert.False(span.EndTime.IsZero())
+
+	assert.Regexp(regexp.MustCompile(`^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`), span.TraceParent())
+	assert.Equal([]string{span.TraceParent(), span.TraceParent()}, traceparents)
+}
+
+func TestClientTracesErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(404)
+	})
+
+	defer ts.Close()
+
+	tracer := &RecordingTracer{}
+	c.Tracer = tracer
+
+	_, err := c.Search().Companies(SearchParams{Query: "test"})
+	assert.ErrorIs(err, ErrNotFound)
+
+	span := tracer.Spans()[0]
+
+	assert.Equal("GET /search/companies", span.Name)
+	assert.Equal(404, span.Attributes[SpanAttrStatusCode])
+	assert.NotContains(span.Attributes, SpanAttrCompanyNumber)
+	assert.Len(span.Errors, 1)
+	assert.ErrorIs(span.Errors[0], ErrNotFound)
+}
+
+func TestClientTracesCacheOutcome(t *testing.T) {
+	assert := assert.New(t)
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(304)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"abc"`)
+		w.Write([]byte(`{}`))
+	})
+
+	defer ts.Close()
+
+	tracer := &RecordingTracer{}
+
+	c.Tracer = tracer
+	c.Cache = NewLRUCache(10)
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Company(EnglishCompanyNo(1)).Profile()
+		assert.NoError(err)
+	}
+
+	c.CachePolicy.TTL = time.Hour
+
+	for i := 0; i < 2; i++ {
+		_, err := c.Company(EnglishCompanyNo(2)).Profile()
+		assert.NoError(err)
+	}
+
+	var outcomes []interface{}
+
+	for _, span := range tracer.Spans() {
+		outcomes = append(outcomes, span.Attributes[SpanAttrCache])
+	}
+
+	assert.Equal([]interface{}{SpanCacheMiss, SpanCacheRevalidated, SpanCacheMiss, SpanCacheHit}, outcomes)
+}
+
+func TestClientPropagatesParentSpan(t *testing.T) {
+	assert := assert.New(t)
+
+	var traceparent string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Write([]byte(`{}`))
+	})
+
+	defer ts.Close()
+
+	tracer := &RecordingTracer{}
+	c.Tracer = tracer
+
+	ctx, parent := tracer.Start(context.Background(), "parent")
+
+	_, err := c.Company(EnglishCompanyNo(1)).ProfileContext(ctx)
+	assert.NoError(err)
+
+	parent.End()
+
+	spans := tracer.Spans()
+	assert.Len(spans, 2)
+
+	child := spans[1]
+
+	assert.Equal(spans[0].TraceID, child.TraceID)
+	assert.Equal(spans[0].SpanID, child.ParentID)
+	assert.Equal(child.TraceParent(), traceparent)
+}
+
+func TestClientWithoutTracer(t *testing.T) {
+	assert := assert.New(t)
+
+	var traceparent []string
+
+	ts, c := createTestServer(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header["Traceparent"]
+		w.Write([]byte(`{}`))
+	})
+
+	defer ts.Close()
+
+	_, err := c.Company(EnglishCompanyNo(1)).Profile()
+
+	assert.NoError(err)
+	assert.Nil(traceparent)
+	assert.Equal(noopSpan{}, SpanFromContext(context.Background()))
+}